@@ -0,0 +1,7 @@
+package model
+
+import "errors"
+
+// ErrInvalidValue is matched by errors.Is against the errors returned by the enum Validate and Parse
+// functions, and through the *service.RequestError a rejected call returns.
+var ErrInvalidValue = errors.New("invalid value")
@@ -0,0 +1,92 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MemberLevel is what a team member is allowed to do, higher levels allowing more. It is sent as its
+// number; zero means no level is set.
+type MemberLevel int
+
+const (
+	MemberLevelIntern MemberLevel = iota + 1
+	MemberLevelEngineer
+	MemberLevelHR
+	MemberLevelManager
+	MemberLevelAdmin
+)
+
+var memberLevelNames = map[MemberLevel]string{
+	MemberLevelIntern:   "intern",
+	MemberLevelEngineer: "engineer",
+	MemberLevelHR:       "hr",
+	MemberLevelManager:  "manager",
+	MemberLevelAdmin:    "admin",
+}
+
+// ParseMemberLevel accepts a level's number or name, e.g. "5" or "admin".
+func ParseMemberLevel(value string) (MemberLevel, error) {
+	return parseLeveled(value, memberLevelNames, "member level")
+}
+
+func (level MemberLevel) String() string {
+	if name, ok := memberLevelNames[level]; ok {
+		return name
+	}
+	return fmt.Sprintf("MemberLevel(%d)", int(level))
+}
+
+// Validate rejects a level that is set but unknown.
+func (level MemberLevel) Validate() error {
+	if _, ok := memberLevelNames[level]; !ok && level != 0 {
+		return fmt.Errorf("%w: member level %d", ErrInvalidValue, int(level))
+	}
+	return nil
+}
+
+func (level MemberLevel) MarshalJSON() ([]byte, error) {
+	return json2.Marshal(int(level))
+}
+
+// UnmarshalJSON takes the number the service sends, or a name. Unknown numbers are kept; Validate
+// rejects them before they are sent back.
+func (level *MemberLevel) UnmarshalJSON(data []byte) error {
+	return unmarshalLeveled(data, level, memberLevelNames, "member level")
+}
+
+// parseLeveled parses the number or case-insensitive name of an int enum.
+func parseLeveled[T ~int](value string, names map[T]string, kind string) (T, error) {
+	value = strings.TrimSpace(value)
+	if number, err := strconv.Atoi(value); err == nil {
+		if _, ok := names[T(number)]; ok {
+			return T(number), nil
+		}
+	}
+	for known, name := range names {
+		if strings.EqualFold(value, name) {
+			return known, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s %q", ErrInvalidValue, kind, value)
+}
+
+func unmarshalLeveled[T ~int](data []byte, target *T, names map[T]string, kind string) error {
+	var number int
+	if err := json2.Unmarshal(data, &number); err == nil {
+		*target = T(number)
+		return nil
+	}
+	var name string
+	if err := json2.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("%s: %w", kind, err)
+	}
+	parsed, err := parseLeveled(name, names, kind)
+	if err != nil {
+		return err
+	}
+	*target = parsed
+	return nil
+}
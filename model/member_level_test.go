@@ -0,0 +1,68 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"testing"
+)
+
+func TestParseMemberLevel(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    MemberLevel
+		wantErr bool
+	}{
+		{value: "5", want: MemberLevelAdmin},
+		{value: "admin", want: MemberLevelAdmin},
+		{value: " HR ", want: MemberLevelHR},
+		{value: "0", wantErr: true},
+		{value: "6", wantErr: true},
+		{value: "owner", wantErr: true},
+	}
+	for _, test := range tests {
+		level, err := ParseMemberLevel(test.value)
+		if level != test.want || (err != nil) != test.wantErr {
+			t.Errorf("ParseMemberLevel(%q) = %d, %v; want %d, error %t", test.value, level, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestMemberLevelValidate(t *testing.T) {
+	tests := []struct {
+		level   MemberLevel
+		wantErr bool
+	}{
+		{level: 0},
+		{level: MemberLevelIntern},
+		{level: MemberLevelAdmin},
+		{level: 6, wantErr: true},
+		{level: -1, wantErr: true},
+	}
+	for _, test := range tests {
+		if err := test.level.Validate(); (err != nil) != test.wantErr {
+			t.Errorf("MemberLevel(%d).Validate() = %v, want error %t", test.level, err, test.wantErr)
+		}
+	}
+}
+
+func TestMemberLevelJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    MemberLevel
+		wantErr bool
+	}{
+		{json: `4`, want: MemberLevelManager},
+		{json: `"engineer"`, want: MemberLevelEngineer},
+		{json: `9`, want: 9},
+		{json: `"owner"`, wantErr: true},
+	}
+	for _, test := range tests {
+		var level MemberLevel
+		err := json2.Unmarshal([]byte(test.json), &level)
+		if level != test.want || (err != nil) != test.wantErr {
+			t.Errorf("Unmarshal(%s) = %d, %v; want %d, error %t", test.json, level, err, test.want, test.wantErr)
+		}
+	}
+	if data, err := json2.Marshal(MemberLevelHR); err != nil || string(data) != "3" {
+		t.Errorf("Marshal(MemberLevelHR) = %s, %v; want 3", data, err)
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"fmt"
+)
+
+// Severity ranks how urgent a ticket is, 1 being the most urgent. It is sent as its number.
+type Severity int
+
+const (
+	SeverityCritical Severity = iota + 1
+	SeverityHigh
+	SeverityMedium
+	SeverityLow
+)
+
+var severityNames = map[Severity]string{
+	SeverityCritical: "critical",
+	SeverityHigh:     "high",
+	SeverityMedium:   "medium",
+	SeverityLow:      "low",
+}
+
+// ParseSeverity accepts a severity's number or name, e.g. "1" or "critical".
+func ParseSeverity(value string) (Severity, error) {
+	return parseLeveled(value, severityNames, "severity")
+}
+
+func (severity Severity) String() string {
+	if name, ok := severityNames[severity]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(severity))
+}
+
+// Validate accepts exactly the severities ParseSeverity does. Unlike the other enums, zero is
+// rejected: every autocut needs a severity.
+func (severity Severity) Validate() error {
+	if _, ok := severityNames[severity]; !ok {
+		return fmt.Errorf("%w: severity %d", ErrInvalidValue, int(severity))
+	}
+	return nil
+}
+
+func (severity Severity) MarshalJSON() ([]byte, error) {
+	return json2.Marshal(int(severity))
+}
+
+// UnmarshalJSON takes the number the service sends, or a name. Unknown numbers are kept so reading a
+// ticket never fails; Validate rejects them before they are sent back.
+func (severity *Severity) UnmarshalJSON(data []byte) error {
+	return unmarshalLeveled(data, severity, severityNames, "severity")
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Severity
+		wantErr bool
+	}{
+		{value: "1", want: SeverityCritical},
+		{value: " 4 ", want: SeverityLow},
+		{value: "High", want: SeverityHigh},
+		{value: "medium", want: SeverityMedium},
+		{value: "0", wantErr: true},
+		{value: "5", wantErr: true},
+		{value: "-2", wantErr: true},
+		{value: "urgent", wantErr: true},
+	}
+	for _, test := range tests {
+		severity, err := ParseSeverity(test.value)
+		if severity != test.want || (err != nil) != test.wantErr {
+			t.Errorf("ParseSeverity(%q) = %d, %v; want %d, error %t", test.value, severity, err, test.want, test.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("ParseSeverity(%q) error %v does not match ErrInvalidValue", test.value, err)
+		}
+	}
+}
+
+func TestSeverityValidate(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		wantErr  bool
+	}{
+		{severity: SeverityCritical},
+		{severity: SeverityLow},
+		{severity: 0, wantErr: true},
+		{severity: 5, wantErr: true},
+		{severity: -1, wantErr: true},
+	}
+	for _, test := range tests {
+		if err := test.severity.Validate(); (err != nil) != test.wantErr {
+			t.Errorf("Severity(%d).Validate() = %v, want error %t", test.severity, err, test.wantErr)
+		}
+		// Validate and ParseSeverity agree on what a severity is.
+		if _, err := ParseSeverity(test.severity.String()); (err != nil) != test.wantErr {
+			t.Errorf("ParseSeverity(%q) = %v, want error %t", test.severity.String(), err, test.wantErr)
+		}
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    Severity
+		wantErr bool
+	}{
+		{json: `2`, want: SeverityHigh},
+		{json: `"critical"`, want: SeverityCritical},
+		{json: `9`, want: 9},
+		{json: `"urgent"`, wantErr: true},
+	}
+	for _, test := range tests {
+		var severity Severity
+		err := json2.Unmarshal([]byte(test.json), &severity)
+		if severity != test.want || (err != nil) != test.wantErr {
+			t.Errorf("Unmarshal(%s) = %d, %v; want %d, error %t", test.json, severity, err, test.want, test.wantErr)
+		}
+	}
+	if data, err := json2.Marshal(SeverityMedium); err != nil || string(data) != "3" {
+		t.Errorf("Marshal(SeverityMedium) = %s, %v; want 3", data, err)
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"strings"
+)
+
+// StatusHistoryEntry records one status transition. TicketModel.StatusHistory holds one entry per line
+// as JSON, oldest first.
+type StatusHistoryEntry struct {
+	From   TicketStatus `json:"from"`
+	To     TicketStatus `json:"to"`
+	Actor  string       `json:"actor"`
+	Reason string       `json:"reason,omitempty"`
+	At     string       `json:"at"`
+}
+
+// AppendStatusHistory adds entry as the last line of StatusHistory.
+func (ticket *TicketModel) AppendStatusHistory(entry StatusHistoryEntry) error {
+	line, err := json2.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if ticket.StatusHistory != "" && !strings.HasSuffix(ticket.StatusHistory, "\n") {
+		ticket.StatusHistory += "\n"
+	}
+	ticket.StatusHistory += string(line)
+	return nil
+}
+
+// StatusHistoryEntries parses StatusHistory oldest first, skipping lines that are not entries such as
+// history written before it was structured.
+func (ticket TicketModel) StatusHistoryEntries() []StatusHistoryEntry {
+	var entries []StatusHistoryEntry
+	for _, line := range strings.Split(ticket.StatusHistory, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var entry StatusHistoryEntry
+		if err := json2.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -10,6 +10,7 @@ type TicketCommentModelCreateRequest struct {
 	UserId             string `json:"user_id"`
 	Message            string `json:"message"`
 	Files              string `json:"files"`
+	IdempotencyKey     string `json:"idempotency_key,omitempty"`
 }
 
 type TicketCommentModelFetchAllRequest struct {
@@ -20,4 +20,15 @@ type TicketModel struct {
 	ResolutionLimit      string `json:"resolution_limit"`
 	CampaignPartitionKey string `json:"campaign_partition_key"`
 	CampaignRangeKey     string `json:"campaign_range_key"`
+	TraceId              string `json:"trace_id,omitempty"`
+}
+
+// TicketStatus returns Status as a TicketStatus; an empty status means it is not set.
+func (ticket TicketModel) TicketStatus() TicketStatus {
+	return normalizeTicketStatus(ticket.Status)
+}
+
+// TicketSeverity returns Severity as a Severity; zero means it is not set.
+func (ticket TicketModel) TicketSeverity() Severity {
+	return Severity(ticket.Severity)
 }
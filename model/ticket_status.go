@@ -0,0 +1,81 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TicketStatus is the lifecycle state of a ticket. It is sent as its name, e.g. "IN_PROGRESS"; the
+// empty status means none is set.
+type TicketStatus string
+
+const (
+	TicketStatusOpen         TicketStatus = "OPEN"
+	TicketStatusAcknowledged TicketStatus = "ACKNOWLEDGED"
+	TicketStatusInProgress   TicketStatus = "IN_PROGRESS"
+	TicketStatusResolved     TicketStatus = "RESOLVED"
+	TicketStatusClosed       TicketStatus = "CLOSED"
+)
+
+// TicketStatuses lists every known status in lifecycle order.
+func TicketStatuses() []TicketStatus {
+	return []TicketStatus{
+		TicketStatusOpen,
+		TicketStatusAcknowledged,
+		TicketStatusInProgress,
+		TicketStatusResolved,
+		TicketStatusClosed,
+	}
+}
+
+// ParseTicketStatus accepts a status name in any case.
+func ParseTicketStatus(value string) (TicketStatus, error) {
+	status := TicketStatus(strings.ToUpper(strings.TrimSpace(value)))
+	if status == "" {
+		return "", fmt.Errorf("%w: empty ticket status", ErrInvalidValue)
+	}
+	if err := status.Validate(); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+func (status TicketStatus) String() string {
+	return string(status)
+}
+
+// Validate rejects a status that is set but unknown.
+func (status TicketStatus) Validate() error {
+	if status == "" {
+		return nil
+	}
+	for _, known := range TicketStatuses() {
+		if status == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: ticket status %q", ErrInvalidValue, string(status))
+}
+
+func (status TicketStatus) MarshalJSON() ([]byte, error) {
+	return json2.Marshal(string(status))
+}
+
+// UnmarshalJSON normalizes known names to upper case and keeps unknown ones as they are, so reading a
+// ticket never fails on a status added later; Validate rejects them before they are sent back.
+func (status *TicketStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json2.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("ticket status: %w", err)
+	}
+	*status = normalizeTicketStatus(value)
+	return nil
+}
+
+func normalizeTicketStatus(value string) TicketStatus {
+	if parsed, err := ParseTicketStatus(value); err == nil {
+		return parsed
+	}
+	return TicketStatus(value)
+}
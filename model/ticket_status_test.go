@@ -0,0 +1,86 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseTicketStatus(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    TicketStatus
+		wantErr bool
+	}{
+		{value: "OPEN", want: TicketStatusOpen},
+		{value: " in_progress ", want: TicketStatusInProgress},
+		{value: "Closed", want: TicketStatusClosed},
+		{value: "", wantErr: true},
+		{value: "DELETED", wantErr: true},
+	}
+	for _, test := range tests {
+		status, err := ParseTicketStatus(test.value)
+		if status != test.want || (err != nil) != test.wantErr {
+			t.Errorf("ParseTicketStatus(%q) = %q, %v; want %q, error %t", test.value, status, err, test.want, test.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("ParseTicketStatus(%q) error %v does not match ErrInvalidValue", test.value, err)
+		}
+	}
+}
+
+func TestTicketStatusValidate(t *testing.T) {
+	tests := []struct {
+		status  TicketStatus
+		wantErr bool
+	}{
+		{status: ""},
+		{status: TicketStatusResolved},
+		{status: "resolved", wantErr: true},
+		{status: "ARCHIVED", wantErr: true},
+	}
+	for _, test := range tests {
+		if err := test.status.Validate(); (err != nil) != test.wantErr {
+			t.Errorf("TicketStatus(%q).Validate() = %v, want error %t", test.status, err, test.wantErr)
+		}
+	}
+}
+
+func TestTicketStatusJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want TicketStatus
+	}{
+		{json: `"OPEN"`, want: TicketStatusOpen},
+		{json: `"acknowledged"`, want: TicketStatusAcknowledged},
+		{json: `"ARCHIVED"`, want: "ARCHIVED"},
+		{json: `""`, want: ""},
+	}
+	for _, test := range tests {
+		var status TicketStatus
+		if err := json2.Unmarshal([]byte(test.json), &status); err != nil || status != test.want {
+			t.Errorf("Unmarshal(%s) = %q, %v; want %q", test.json, status, err, test.want)
+		}
+	}
+	if err := json2.Unmarshal([]byte(`3`), new(TicketStatus)); err == nil {
+		t.Error("Unmarshal(3) succeeded, want an error")
+	}
+}
+
+func TestTicketModelAccessors(t *testing.T) {
+	tests := []struct {
+		ticket       TicketModel
+		wantStatus   TicketStatus
+		wantSeverity Severity
+	}{
+		{ticket: TicketModel{Status: "in_progress", Severity: 2}, wantStatus: TicketStatusInProgress, wantSeverity: 2},
+		{ticket: TicketModel{Status: "ARCHIVED"}, wantStatus: "ARCHIVED"},
+		{ticket: TicketModel{}},
+	}
+	for _, test := range tests {
+		if status, severity := test.ticket.TicketStatus(), test.ticket.TicketSeverity(); status != test.wantStatus || severity != test.wantSeverity {
+			t.Errorf("%+v: TicketStatus %q, TicketSeverity %d; want %q, %d",
+				test.ticket, status, severity, test.wantStatus, test.wantSeverity)
+		}
+	}
+}
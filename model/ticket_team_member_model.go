@@ -17,3 +17,8 @@ type TicketTeamMemberModel struct {
 	// What the user can do; 5= admin, 4 = manager, 3 = hr, 2 = engineer, 1 = intern
 	Level int `json:"level"`
 }
+
+// MemberLevel returns Level as a MemberLevel; zero means it is not set.
+func (member TicketTeamMemberModel) MemberLevel() MemberLevel {
+	return MemberLevel(member.Level)
+}
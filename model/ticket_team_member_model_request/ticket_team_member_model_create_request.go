@@ -10,4 +10,5 @@ type TicketTeamMemberModelCreateRequest struct {
 	UserId          string `json:"user_id"`
 	Status          string `json:"status"`
 	Level           int    `json:"level"`
+	IdempotencyKey  string `json:"idempotency_key,omitempty"`
 }
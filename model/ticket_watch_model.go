@@ -12,3 +12,13 @@ type TicketWatchModel struct {
 	Created       string `json:"created"`
 	Modified      string `json:"modified"`
 }
+
+// WatchRole returns Role as a WatchRole; an empty role means it is not set.
+func (watch TicketWatchModel) WatchRole() WatchRole {
+	return normalizeWatchRole(watch.Role)
+}
+
+// WatchedTicketStatus returns TicketStatus as a TicketStatus; an empty status means it is not set.
+func (watch TicketWatchModel) WatchedTicketStatus() TicketStatus {
+	return normalizeTicketStatus(watch.TicketStatus)
+}
@@ -5,6 +5,7 @@ type TicketWatchAddRequest struct {
 	TicketPartitionKey string `json:"ticket_partition_key"`
 	TicketRangeKey     string `json:"ticket_range_key"`
 	Role               string `json:"role"`
+	IdempotencyKey     string `json:"idempotency_key,omitempty"`
 }
 
 type TicketWatchRemoveRequest struct {
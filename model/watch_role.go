@@ -0,0 +1,72 @@
+package model
+
+import (
+	json2 "encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WatchRole is why a user watches a ticket. It is sent as its name, e.g. "ASSIGNEE"; the empty role
+// means none is set.
+type WatchRole string
+
+const (
+	WatchRoleWatcher  WatchRole = "WATCHER"
+	WatchRoleReporter WatchRole = "REPORTER"
+	WatchRoleAssignee WatchRole = "ASSIGNEE"
+)
+
+func WatchRoles() []WatchRole {
+	return []WatchRole{WatchRoleWatcher, WatchRoleReporter, WatchRoleAssignee}
+}
+
+// ParseWatchRole accepts a role name in any case.
+func ParseWatchRole(value string) (WatchRole, error) {
+	role := WatchRole(strings.ToUpper(strings.TrimSpace(value)))
+	if role == "" {
+		return "", fmt.Errorf("%w: empty watch role", ErrInvalidValue)
+	}
+	if err := role.Validate(); err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+func (role WatchRole) String() string {
+	return string(role)
+}
+
+// Validate rejects a role that is set but unknown.
+func (role WatchRole) Validate() error {
+	if role == "" {
+		return nil
+	}
+	for _, known := range WatchRoles() {
+		if role == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: watch role %q", ErrInvalidValue, string(role))
+}
+
+func (role WatchRole) MarshalJSON() ([]byte, error) {
+	return json2.Marshal(string(role))
+}
+
+// UnmarshalJSON normalizes known names to upper case and keeps unknown ones as they are; Validate
+// rejects them before they are sent back.
+func (role *WatchRole) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json2.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("watch role: %w", err)
+	}
+	*role = normalizeWatchRole(value)
+	return nil
+}
+
+func normalizeWatchRole(value string) WatchRole {
+	if parsed, err := ParseWatchRole(value); err == nil {
+		return parsed
+	}
+	return WatchRole(value)
+}
@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestParseWatchRole(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    WatchRole
+		wantErr bool
+	}{
+		{value: "ASSIGNEE", want: WatchRoleAssignee},
+		{value: " watcher ", want: WatchRoleWatcher},
+		{value: "", wantErr: true},
+		{value: "OWNER", wantErr: true},
+	}
+	for _, test := range tests {
+		role, err := ParseWatchRole(test.value)
+		if role != test.want || (err != nil) != test.wantErr {
+			t.Errorf("ParseWatchRole(%q) = %q, %v; want %q, error %t", test.value, role, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestTicketWatchModelAccessors(t *testing.T) {
+	tests := []struct {
+		watch      TicketWatchModel
+		wantRole   WatchRole
+		wantStatus TicketStatus
+	}{
+		{watch: TicketWatchModel{Role: "reporter", TicketStatus: "closed"}, wantRole: WatchRoleReporter, wantStatus: TicketStatusClosed},
+		{watch: TicketWatchModel{Role: "OWNER", TicketStatus: "ARCHIVED"}, wantRole: "OWNER", wantStatus: "ARCHIVED"},
+		{watch: TicketWatchModel{}},
+	}
+	for _, test := range tests {
+		role, status := test.watch.WatchRole(), test.watch.WatchedTicketStatus()
+		if role != test.wantRole || status != test.wantStatus {
+			t.Errorf("%+v: WatchRole %q, WatchedTicketStatus %q; want %q, %q", test.watch, role, status, test.wantRole, test.wantStatus)
+		}
+		if err := role.Validate(); (err != nil) != (role == "OWNER") {
+			t.Errorf("WatchRole(%q).Validate() = %v", role, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	digitRun      = regexp.MustCompile(`[0-9]+`)
+	whitespaceRun = regexp.MustCompile(`\s+`)
+)
+
+// AutocutFingerprint identifies "the same failure" across autocuts. The description is normalized
+// so that timestamps, ids and counters embedded in it do not defeat deduplication.
+func AutocutFingerprint(title string, description string, severity int, teamId string) string {
+	normalizedDescription := strings.ToLower(description)
+	normalizedDescription = digitRun.ReplaceAllString(normalizedDescription, "#")
+	normalizedDescription = strings.TrimSpace(whitespaceRun.ReplaceAllString(normalizedDescription, " "))
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strings.TrimSpace(title),
+		normalizedDescription,
+		fmt.Sprintf("%d", severity),
+		teamId,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// DefaultOccurrenceCommentInterval is the least time between two "Autocut repeated" comments on a
+// ticket. Repeats in between are counted and folded into the next comment.
+const DefaultOccurrenceCommentInterval = time.Minute
+
+// AutocutDeduplicator remembers the ticket opened for each fingerprint for a fixed window.
+type AutocutDeduplicator struct {
+	window time.Duration
+	// commentInterval spaces out the occurrence comments posted for one fingerprint.
+	commentInterval time.Duration
+	now             func() time.Time
+	mu              sync.Mutex
+	entries         map[string]*autocutOccurrence
+}
+
+type autocutOccurrence struct {
+	ticket    model.TicketModel
+	firstSeen time.Time
+	count     int
+	// commented is the count the last occurrence comment covered, and commentedAt when it was posted.
+	commented   int
+	commentedAt time.Time
+	// comment is set on the occurrence handed to the caller that should post the next comment; it
+	// covers the occurrences after commented up to count.
+	comment bool
+	// pending is true while the first autocut for the fingerprint is still being created;
+	// done is closed once it succeeds or fails.
+	pending bool
+	done    chan struct{}
+}
+
+func NewAutocutDeduplicator(window time.Duration) *AutocutDeduplicator {
+	return &AutocutDeduplicator{
+		window:          window,
+		commentInterval: DefaultOccurrenceCommentInterval,
+		now:             time.Now,
+		entries:         map[string]*autocutOccurrence{},
+	}
+}
+
+// claim reports whether fingerprint already has a ticket in the current window. When it does not,
+// the caller owns creating it and must follow up with record or release. Callers that arrive while
+// the ticket is still being created wait for that outcome. At most one duplicate per commentInterval
+// is marked to comment.
+func (deduplicator *AutocutDeduplicator) claim(ctx context.Context, fingerprint string) (autocutOccurrence, bool, error) {
+	for {
+		deduplicator.mu.Lock()
+		now := deduplicator.now()
+		deduplicator.sweep(now)
+		entry, ok := deduplicator.entries[fingerprint]
+		if !ok {
+			entry = &autocutOccurrence{firstSeen: now, count: 1, commented: 1, pending: true, done: make(chan struct{})}
+			deduplicator.entries[fingerprint] = entry
+			deduplicator.mu.Unlock()
+			return *entry, false, nil
+		}
+		if entry.pending {
+			done := entry.done
+			deduplicator.mu.Unlock()
+			select {
+			case <-done:
+				continue
+			case <-ctx.Done():
+				return autocutOccurrence{}, false, ctx.Err()
+			}
+		}
+		entry.count++
+		occurrence := *entry
+		if entry.commentedAt.IsZero() || now.Sub(entry.commentedAt) >= deduplicator.commentInterval {
+			occurrence.comment = true
+			entry.commented = entry.count
+			entry.commentedAt = now
+		}
+		deduplicator.mu.Unlock()
+		return occurrence, true, nil
+	}
+}
+
+// record stores the ticket created for a claimed fingerprint.
+func (deduplicator *AutocutDeduplicator) record(fingerprint string, ticket model.TicketModel) {
+	deduplicator.mu.Lock()
+	defer deduplicator.mu.Unlock()
+	entry, ok := deduplicator.entries[fingerprint]
+	if !ok || !entry.pending {
+		return
+	}
+	entry.ticket = ticket
+	entry.pending = false
+	close(entry.done)
+}
+
+// release forgets a claimed fingerprint whose ticket could not be created so the next caller retries.
+func (deduplicator *AutocutDeduplicator) release(fingerprint string) {
+	deduplicator.mu.Lock()
+	defer deduplicator.mu.Unlock()
+	entry, ok := deduplicator.entries[fingerprint]
+	if !ok || !entry.pending {
+		return
+	}
+	delete(deduplicator.entries, fingerprint)
+	close(entry.done)
+}
+
+// sweep drops settled entries older than the window. Callers must hold deduplicator.mu.
+func (deduplicator *AutocutDeduplicator) sweep(now time.Time) {
+	for fingerprint, entry := range deduplicator.entries {
+		if !entry.pending && now.Sub(entry.firstSeen) >= deduplicator.window {
+			delete(deduplicator.entries, fingerprint)
+		}
+	}
+}
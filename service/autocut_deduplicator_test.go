@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"testing"
+	"time"
+)
+
+func TestAutocutFingerprint(t *testing.T) {
+	base := AutocutFingerprint("Disk full", "host 12 at 2024-01-02T03:04:05Z", 2, "team")
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		severity    int
+		teamId      string
+		wantSame    bool
+	}{
+		{name: "other numbers", title: "Disk full", description: "host 7 at 2025-11-12T13:14:15Z", severity: 2, teamId: "team", wantSame: true},
+		{name: "case and spacing", title: " Disk full ", description: "HOST 1   at 1-2-3T4:5:6Z", severity: 2, teamId: "team", wantSame: true},
+		{name: "other title", title: "Disk almost full", description: "host 12 at 2024-01-02T03:04:05Z", severity: 2, teamId: "team"},
+		{name: "other severity", title: "Disk full", description: "host 12 at 2024-01-02T03:04:05Z", severity: 3, teamId: "team"},
+		{name: "other team", title: "Disk full", description: "host 12 at 2024-01-02T03:04:05Z", severity: 2, teamId: "other"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			same := AutocutFingerprint(test.title, test.description, test.severity, test.teamId) == base
+			if same != test.wantSame {
+				t.Errorf("same fingerprint = %t, want %t", same, test.wantSame)
+			}
+		})
+	}
+}
+
+func TestAutocutDeduplicatorClaim(t *testing.T) {
+	type step struct {
+		advance time.Duration
+		// settle is what the owner of a fresh claim does: "record" or "release".
+		settle        string
+		wantDuplicate bool
+		wantCount     int
+		wantComment   bool
+		// wantCommented is the count the previous comment covered, for a duplicate that comments.
+		wantCommented int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "repeats within the window are duplicates",
+			steps: []step{
+				{settle: "record", wantCount: 1},
+				{advance: time.Second, wantDuplicate: true, wantCount: 2, wantComment: true, wantCommented: 1},
+				{advance: time.Second, wantDuplicate: true, wantCount: 3},
+			},
+		},
+		{
+			name: "the window starts a new ticket",
+			steps: []step{
+				{settle: "record", wantCount: 1},
+				{advance: 10 * time.Minute, settle: "record", wantCount: 1},
+				{advance: time.Second, wantDuplicate: true, wantCount: 2, wantComment: true, wantCommented: 1},
+			},
+		},
+		{
+			name: "a released claim is retried",
+			steps: []step{
+				{settle: "release", wantCount: 1},
+				{settle: "record", wantCount: 1},
+				{wantDuplicate: true, wantCount: 2, wantComment: true, wantCommented: 1},
+			},
+		},
+		{
+			name: "comments are spaced out and cover the repeats in between",
+			steps: []step{
+				{settle: "record", wantCount: 1},
+				{wantDuplicate: true, wantCount: 2, wantComment: true, wantCommented: 1},
+				{advance: 20 * time.Second, wantDuplicate: true, wantCount: 3},
+				{advance: 20 * time.Second, wantDuplicate: true, wantCount: 4},
+				{advance: 20 * time.Second, wantDuplicate: true, wantCount: 5, wantComment: true, wantCommented: 2},
+				{advance: time.Second, wantDuplicate: true, wantCount: 6},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &fakeClock{at: time.Unix(0, 0)}
+			deduplicator := NewAutocutDeduplicator(10 * time.Minute)
+			deduplicator.now = clock.now
+			for index, step := range test.steps {
+				clock.advance(step.advance)
+				occurrence, duplicate, err := deduplicator.claim(context.Background(), "fingerprint")
+				if err != nil {
+					t.Fatalf("step %d: claim: %v", index, err)
+				}
+				if duplicate != step.wantDuplicate || occurrence.count != step.wantCount || occurrence.comment != step.wantComment {
+					t.Fatalf("step %d: duplicate %t, count %d, comment %t; want %t, %d, %t", index,
+						duplicate, occurrence.count, occurrence.comment, step.wantDuplicate, step.wantCount, step.wantComment)
+				}
+				if step.wantComment && occurrence.commented != step.wantCommented {
+					t.Fatalf("step %d: previous comment covered %d, want %d", index, occurrence.commented, step.wantCommented)
+				}
+				switch step.settle {
+				case "record":
+					deduplicator.record("fingerprint", model.TicketModel{PartitionKey: "pk", RangeKey: "rk"})
+				case "release":
+					deduplicator.release("fingerprint")
+				}
+			}
+		})
+	}
+}
+
+func TestAutocutDeduplicatorWaitsForPendingClaim(t *testing.T) {
+	tests := []struct {
+		name          string
+		settle        func(*AutocutDeduplicator)
+		wantDuplicate bool
+	}{
+		{
+			name: "recorded",
+			settle: func(deduplicator *AutocutDeduplicator) {
+				deduplicator.record("fingerprint", model.TicketModel{PartitionKey: "pk", RangeKey: "rk"})
+			},
+			wantDuplicate: true,
+		},
+		{
+			name: "released",
+			settle: func(deduplicator *AutocutDeduplicator) {
+				deduplicator.release("fingerprint")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deduplicator := NewAutocutDeduplicator(time.Minute)
+			if _, duplicate, _ := deduplicator.claim(context.Background(), "fingerprint"); duplicate {
+				t.Fatal("first claim is a duplicate")
+			}
+			type claimed struct {
+				occurrence autocutOccurrence
+				duplicate  bool
+			}
+			results := make(chan claimed)
+			go func() {
+				occurrence, duplicate, _ := deduplicator.claim(context.Background(), "fingerprint")
+				results <- claimed{occurrence, duplicate}
+			}()
+			select {
+			case <-results:
+				t.Fatal("second claim returned while the first was pending")
+			case <-time.After(10 * time.Millisecond):
+			}
+			test.settle(deduplicator)
+			result := <-results
+			if result.duplicate != test.wantDuplicate {
+				t.Fatalf("duplicate = %t, want %t", result.duplicate, test.wantDuplicate)
+			}
+			if test.wantDuplicate && result.occurrence.ticket.RangeKey != "rk" {
+				t.Errorf("duplicate points at %+v, want the recorded ticket", result.occurrence.ticket)
+			}
+		})
+	}
+}
+
+func TestAutocutDeduplicatorClaimHonoursContext(t *testing.T) {
+	deduplicator := NewAutocutDeduplicator(time.Minute)
+	deduplicator.claim(context.Background(), "fingerprint")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := deduplicator.claim(ctx, "fingerprint"); err != context.Canceled {
+		t.Errorf("claim while pending with a cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AutocutRateLimit caps how many autocuts a process emits per minute. Zero values mean "no limit".
+type AutocutRateLimit struct {
+	// PerMinute is the process-wide ceiling shared by every severity that is not exempt.
+	PerMinute int
+	// SeverityPerMinute adds a tighter budget for individual severities.
+	SeverityPerMinute map[int]int
+	// ExemptSeverities are never throttled and do not consume the process-wide ceiling.
+	ExemptSeverities []int
+	// Queue makes throttled calls wait for a token, bounded by their context, instead of dropping.
+	Queue bool
+}
+
+// DefaultAutocutRateLimit always lets severity 1 through and throttles severity 4 hardest.
+func DefaultAutocutRateLimit() AutocutRateLimit {
+	return AutocutRateLimit{
+		PerMinute:         30,
+		SeverityPerMinute: map[int]int{2: 20, 3: 10, 4: 2},
+		ExemptSeverities:  []int{1},
+	}
+}
+
+// tokenBucket refills perMinute tokens per minute up to a burst of perMinute.
+type tokenBucket struct {
+	capacity   float64
+	perSecond  float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(perMinute int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity:   float64(perMinute),
+		perSecond:  float64(perMinute) / 60,
+		tokens:     float64(perMinute),
+		lastRefill: now,
+	}
+}
+
+func (bucket *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = min(bucket.capacity, bucket.tokens+elapsed*bucket.perSecond)
+		bucket.lastRefill = now
+	}
+}
+
+// untilToken is how long until the bucket holds a whole token.
+func (bucket *tokenBucket) untilToken() time.Duration {
+	if bucket.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) / bucket.perSecond * float64(time.Second))
+}
+
+// AutocutLimiter applies an AutocutRateLimit and counts what it drops.
+type AutocutLimiter struct {
+	limit    AutocutRateLimit
+	now      func() time.Time
+	mu       sync.Mutex
+	global   *tokenBucket
+	severity map[int]*tokenBucket
+	exempt   map[int]bool
+	dropped  map[int]int64
+}
+
+func NewAutocutLimiter(limit AutocutRateLimit) *AutocutLimiter {
+	limiter := &AutocutLimiter{
+		limit:    limit,
+		now:      time.Now,
+		severity: map[int]*tokenBucket{},
+		exempt:   map[int]bool{},
+		dropped:  map[int]int64{},
+	}
+	now := limiter.now()
+	if limit.PerMinute > 0 {
+		limiter.global = newTokenBucket(limit.PerMinute, now)
+	}
+	for severity, perMinute := range limit.SeverityPerMinute {
+		if perMinute > 0 {
+			limiter.severity[severity] = newTokenBucket(perMinute, now)
+		}
+	}
+	for _, severity := range limit.ExemptSeverities {
+		limiter.exempt[severity] = true
+	}
+	return limiter
+}
+
+// Allow takes a token for severity. With Queue set it waits for one until ctx is done; otherwise it
+// returns false straight away. The second return value is the running drop count for severity.
+func (limiter *AutocutLimiter) Allow(ctx context.Context, severity int) (bool, int64) {
+	if limiter.limit.Queue {
+		if limiter.Wait(ctx, severity) == nil {
+			return true, 0
+		}
+	} else if _, ok := limiter.take(severity); ok {
+		return true, 0
+	}
+	limiter.mu.Lock()
+	limiter.dropped[severity]++
+	dropped := limiter.dropped[severity]
+	limiter.mu.Unlock()
+	return false, dropped
+}
+
+// Wait takes a token for severity, waiting for one until ctx is done whatever Queue says. Nothing is
+// counted as dropped when ctx ends first.
+func (limiter *AutocutLimiter) Wait(ctx context.Context, severity int) error {
+	for {
+		wait, ok := limiter.take(severity)
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Dropped returns how many autocuts of severity have been throttled so far.
+func (limiter *AutocutLimiter) Dropped(severity int) int64 {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.dropped[severity]
+}
+
+// take consumes a token from the severity and global buckets together, or reports how long to wait.
+func (limiter *AutocutLimiter) take(severity int) (time.Duration, bool) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if limiter.exempt[severity] {
+		return 0, true
+	}
+	now := limiter.now()
+	buckets := make([]*tokenBucket, 0, 2)
+	if bucket, ok := limiter.severity[severity]; ok {
+		buckets = append(buckets, bucket)
+	}
+	if limiter.global != nil {
+		buckets = append(buckets, limiter.global)
+	}
+	var wait time.Duration
+	for _, bucket := range buckets {
+		bucket.refill(now)
+		wait = max(wait, bucket.untilToken())
+	}
+	if wait > 0 {
+		return wait, false
+	}
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return 0, true
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAutocutLimiter(t *testing.T) {
+	type attempt struct {
+		advance  time.Duration
+		severity int
+		want     bool
+	}
+	tests := []struct {
+		name        string
+		limit       AutocutRateLimit
+		attempts    []attempt
+		wantDropped map[int]int64
+	}{
+		{
+			name:  "no limit",
+			limit: AutocutRateLimit{},
+			attempts: []attempt{
+				{severity: 3, want: true},
+				{severity: 3, want: true},
+				{severity: 3, want: true},
+			},
+		},
+		{
+			name:  "process-wide ceiling",
+			limit: AutocutRateLimit{PerMinute: 2},
+			attempts: []attempt{
+				{severity: 2, want: true},
+				{severity: 3, want: true},
+				{severity: 4, want: false},
+				{advance: 30 * time.Second, severity: 4, want: true},
+				{severity: 4, want: false},
+			},
+			wantDropped: map[int]int64{4: 2},
+		},
+		{
+			name:  "severity budget is tighter than the ceiling",
+			limit: AutocutRateLimit{PerMinute: 10, SeverityPerMinute: map[int]int{4: 1}},
+			attempts: []attempt{
+				{severity: 4, want: true},
+				{severity: 4, want: false},
+				{severity: 3, want: true},
+			},
+			wantDropped: map[int]int64{4: 1},
+		},
+		{
+			name:  "a throttled severity does not spend the ceiling",
+			limit: AutocutRateLimit{PerMinute: 2, SeverityPerMinute: map[int]int{4: 1}},
+			attempts: []attempt{
+				{severity: 4, want: true},
+				{severity: 4, want: false},
+				{severity: 4, want: false},
+				{severity: 2, want: true},
+				{severity: 2, want: false},
+			},
+			wantDropped: map[int]int64{2: 1, 4: 2},
+		},
+		{
+			name:  "exempt severities pass and leave the ceiling alone",
+			limit: AutocutRateLimit{PerMinute: 1, SeverityPerMinute: map[int]int{1: 1}, ExemptSeverities: []int{1}},
+			attempts: []attempt{
+				{severity: 1, want: true},
+				{severity: 1, want: true},
+				{severity: 1, want: true},
+				{severity: 2, want: true},
+				{severity: 2, want: false},
+				{severity: 1, want: true},
+			},
+			wantDropped: map[int]int64{2: 1},
+		},
+		{
+			name:  "defaults",
+			limit: DefaultAutocutRateLimit(),
+			attempts: []attempt{
+				{severity: 4, want: true},
+				{severity: 4, want: true},
+				{severity: 4, want: false},
+				{severity: 1, want: true},
+			},
+			wantDropped: map[int]int64{4: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := NewAutocutLimiter(test.limit)
+			// The buckets were filled at the real time, so the clock starts from there.
+			clock := &fakeClock{at: time.Now()}
+			limiter.now = clock.now
+			for index, attempt := range test.attempts {
+				clock.advance(attempt.advance)
+				if allowed, _ := limiter.Allow(context.Background(), attempt.severity); allowed != attempt.want {
+					t.Fatalf("attempt %d: severity %d allowed = %t, want %t", index, attempt.severity, allowed, attempt.want)
+				}
+			}
+			for severity := 1; severity <= 4; severity++ {
+				if dropped := limiter.Dropped(severity); dropped != test.wantDropped[severity] {
+					t.Errorf("Dropped(%d) = %d, want %d", severity, dropped, test.wantDropped[severity])
+				}
+			}
+		})
+	}
+}
+
+func TestAutocutLimiterQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    bool
+	}{
+		{name: "waits for a token", timeout: time.Second, want: true},
+		{name: "gives up with the context", timeout: time.Millisecond, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// 600 a minute refills a token every 100ms.
+			limiter := NewAutocutLimiter(AutocutRateLimit{SeverityPerMinute: map[int]int{3: 600}, Queue: true})
+			for taken := 0; taken < 600; taken++ {
+				limiter.take(3)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			if allowed, _ := limiter.Allow(ctx, 3); allowed != test.want {
+				t.Errorf("allowed = %t, want %t", allowed, test.want)
+			}
+		})
+	}
+}
+
+func TestAutocutLimiterWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr error
+	}{
+		{name: "waits for a token without Queue", timeout: time.Second},
+		{name: "gives up with the context", timeout: time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// 600 a minute refills a token every 100ms.
+			limiter := NewAutocutLimiter(AutocutRateLimit{SeverityPerMinute: map[int]int{3: 600}})
+			for taken := 0; taken < 600; taken++ {
+				limiter.take(3)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			if err := limiter.Wait(ctx, 3); err != test.wantErr {
+				t.Errorf("Wait = %v, want %v", err, test.wantErr)
+			}
+			if dropped := limiter.Dropped(3); dropped != 0 {
+				t.Errorf("Dropped = %d after Wait, want 0", dropped)
+			}
+		})
+	}
+}
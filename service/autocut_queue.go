@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	"log/slog"
+	"sync"
+)
+
+type autocutJob struct {
+	title       string
+	description string
+	files       string
+	severity    int
+}
+
+// AutocutQueue sends autocuts from background workers so callers never block on the network.
+// When the buffer is full new autocuts are dropped and counted.
+type AutocutQueue struct {
+	ticketService  TicketServiceContract
+	metricsManager metrics.MetricsManagerContract
+	logger         *slog.Logger
+	jobs           chan autocutJob
+	ctx            context.Context
+	cancel         context.CancelFunc
+	workers        sync.WaitGroup
+	mu             sync.Mutex
+	closed         bool
+	pending        int
+	idle           chan struct{}
+	dropped        int64
+}
+
+func NewAutocutQueue(
+	ticketService TicketServiceContract,
+	metricsManager metrics.MetricsManagerContract,
+	bufferSize int,
+	workers int,
+) *AutocutQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := &AutocutQueue{
+		ticketService:  ticketService,
+		metricsManager: metricsManager,
+		logger:         loggerOrDiscard(nil),
+		jobs:           make(chan autocutJob, max(bufferSize, 1)),
+		ctx:            ctx,
+		cancel:         cancel,
+		idle:           make(chan struct{}),
+	}
+	close(queue.idle)
+	for i := 0; i < max(workers, 1); i++ {
+		queue.workers.Add(1)
+		go queue.work()
+	}
+	return queue
+}
+
+// SetLogger sends the queue's records to logger. Call it before the first Enqueue.
+func (queue *AutocutQueue) SetLogger(logger *slog.Logger) {
+	queue.logger = loggerOrDiscard(logger)
+}
+
+// Enqueue never blocks. It returns false when the queue is full or closed.
+func (queue *AutocutQueue) Enqueue(title string, description string, files string, severity int) bool {
+	queue.mu.Lock()
+	if queue.closed {
+		queue.mu.Unlock()
+		queue.logger.Warn("CLOSED", "method", "AutocutQueue.Enqueue", "severity", severity)
+		return false
+	}
+	select {
+	case queue.jobs <- autocutJob{title: title, description: description, files: files, severity: severity}:
+		if queue.pending == 0 {
+			queue.idle = make(chan struct{})
+		}
+		queue.pending++
+		queue.mu.Unlock()
+		return true
+	default:
+	}
+	queue.dropped++
+	dropped := queue.dropped
+	depth := len(queue.jobs)
+	queue.mu.Unlock()
+	queue.logger.Warn("DROPPED", "method", "AutocutQueue.Enqueue", "severity", severity, "depth", depth, "dropped", dropped)
+	if queue.metricsManager == nil {
+		return false
+	}
+	queue.metricsManager.Send500Error(
+		"CincinnatiTicketService.autocutQueue",
+		503,
+		fmt.Sprintf("Autocut queue full, Depth: %d, Dropped: %d", depth, dropped))
+	return false
+}
+
+// Depth is the number of autocuts waiting for a worker.
+func (queue *AutocutQueue) Depth() int {
+	return len(queue.jobs)
+}
+
+// Dropped is the number of autocuts rejected because the queue was full.
+func (queue *AutocutQueue) Dropped() int64 {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return queue.dropped
+}
+
+// Flush waits until every autocut enqueued so far has been sent or ctx is done.
+func (queue *AutocutQueue) Flush(ctx context.Context) error {
+	queue.mu.Lock()
+	idle := queue.idle
+	queue.mu.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting autocuts and drains the queue. If ctx is done first, in-flight calls are
+// cancelled, whatever is still buffered is abandoned and ctx.Err() is returned.
+func (queue *AutocutQueue) Close(ctx context.Context) error {
+	queue.mu.Lock()
+	if !queue.closed {
+		queue.closed = true
+		close(queue.jobs)
+	}
+	queue.mu.Unlock()
+	finished := make(chan struct{})
+	go func() {
+		queue.workers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		queue.cancel()
+		return nil
+	case <-ctx.Done():
+		queue.cancel()
+		queue.logger.ErrorContext(ctx, "ABANDONED", "method", "AutocutQueue.Close", "depth", queue.Depth(), "error", ctx.Err())
+		return ctx.Err()
+	}
+}
+
+func (queue *AutocutQueue) work() {
+	defer queue.workers.Done()
+	for job := range queue.jobs {
+		if queue.ctx.Err() == nil {
+			queue.ticketService.CreateAutocutCtx(queue.ctx, job.title, job.description, job.files, job.severity)
+		}
+		queue.mu.Lock()
+		queue.pending--
+		if queue.pending == 0 {
+			close(queue.idle)
+		}
+		queue.mu.Unlock()
+	}
+}
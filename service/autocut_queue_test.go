@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/tickettest"
+	"testing"
+	"time"
+)
+
+// blockingTicketService holds every CreateAutocutCtx until release is closed or its ctx is done.
+type blockingTicketService struct {
+	*tickettest.TicketService
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingTicketService(backend *tickettest.Backend) *blockingTicketService {
+	return &blockingTicketService{
+		TicketService: tickettest.NewTicketService(backend, "client", "team", "autocut"),
+		started:       make(chan struct{}, 100),
+		release:       make(chan struct{}),
+	}
+}
+
+func (blocking *blockingTicketService) CreateAutocutCtx(ctx context.Context, title string, description string, files string, severity int) bool {
+	blocking.started <- struct{}{}
+	select {
+	case <-blocking.release:
+	case <-ctx.Done():
+		return false
+	}
+	return blocking.TicketService.CreateAutocutCtx(ctx, title, description, files, severity)
+}
+
+func TestAutocutQueueEnqueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		bufferSize   int
+		enqueue      int
+		wantAccepted int
+	}{
+		{name: "fits the buffer", bufferSize: 3, enqueue: 3, wantAccepted: 3},
+		{name: "drops past the buffer", bufferSize: 2, enqueue: 5, wantAccepted: 2},
+		{name: "buffer of at least one", bufferSize: 0, enqueue: 2, wantAccepted: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			blocking := newBlockingTicketService(backend)
+			queue := service.NewAutocutQueue(blocking, ticketmetrics.NoopMetricsManager{}, test.bufferSize, 1)
+			defer queue.Close(context.Background())
+			// The single worker takes the first autocut and holds it, so the rest wait in the buffer.
+			if !queue.Enqueue("held", "description", "", 3) {
+				t.Fatal("Enqueue into an empty queue failed")
+			}
+			<-blocking.started
+			accepted := 0
+			for index := 0; index < test.enqueue; index++ {
+				if queue.Enqueue("title", "description", "", 3) {
+					accepted++
+				}
+			}
+			if accepted != test.wantAccepted || queue.Depth() != test.wantAccepted {
+				t.Fatalf("accepted %d with depth %d, want %d", accepted, queue.Depth(), test.wantAccepted)
+			}
+			if dropped := queue.Dropped(); dropped != int64(test.enqueue-test.wantAccepted) {
+				t.Errorf("Dropped = %d, want %d", dropped, test.enqueue-test.wantAccepted)
+			}
+			close(blocking.release)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := queue.Flush(ctx); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+			if tickets := len(backend.Tickets()); tickets != test.wantAccepted+1 {
+				t.Errorf("%d tickets created, want %d", tickets, test.wantAccepted+1)
+			}
+		})
+	}
+}
+
+func TestAutocutQueueClose(t *testing.T) {
+	tests := []struct {
+		name        string
+		release     bool
+		timeout     time.Duration
+		wantErr     error
+		wantTickets int
+	}{
+		{name: "drains what was enqueued", release: true, timeout: 5 * time.Second, wantTickets: 3},
+		{name: "abandons the rest when ctx is done", timeout: 10 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			blocking := newBlockingTicketService(backend)
+			queue := service.NewAutocutQueue(blocking, nil, 10, 1)
+			for index := 0; index < 3; index++ {
+				queue.Enqueue("title", "description", "", 3)
+			}
+			if test.release {
+				close(blocking.release)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			if err := queue.Close(ctx); err != test.wantErr {
+				t.Fatalf("Close = %v, want %v", err, test.wantErr)
+			}
+			if queue.Enqueue("late", "description", "", 3) {
+				t.Error("Enqueue after Close was accepted")
+			}
+			if tickets := len(backend.Tickets()); tickets != test.wantTickets {
+				t.Errorf("%d tickets created, want %d", tickets, test.wantTickets)
+			}
+		})
+	}
+}
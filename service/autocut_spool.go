@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	json2 "encoding/json"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	spoolFileExtension  = ".json"
+	spoolMinBackoff     = time.Second
+	spoolMaxBackoff     = 5 * time.Minute
+	spoolIdleInterval   = 30 * time.Second
+	spoolTempFilePrefix = ".tmp-"
+	// spoolFingerprintLength is how many hex digits of the autocut fingerprint name a record.
+	spoolFingerprintLength = 16
+)
+
+// spoolRecord is the on-disk form of one autocut waiting to be replayed.
+type spoolRecord struct {
+	Request   ticket_model_request.TicketModelCreateRequest `json:"request"`
+	SpooledAt string                                        `json:"spooled_at"`
+	Attempts  int                                           `json:"attempts"`
+}
+
+// AutocutSpool persists autocuts that could not be created to a directory, one JSON file per
+// record, so they survive restarts and can be replayed once the ticket service recovers.
+// A directory should be used by a single process at a time.
+type AutocutSpool struct {
+	dir          string
+	now          func() time.Time
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	idleInterval time.Duration
+	logger       *slog.Logger
+	mu           sync.Mutex
+	wake         chan struct{}
+}
+
+func NewAutocutSpool(dir string) (*AutocutSpool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create autocut spool %s: %w", dir, err)
+	}
+	return &AutocutSpool{
+		dir:          dir,
+		now:          time.Now,
+		minBackoff:   spoolMinBackoff,
+		maxBackoff:   spoolMaxBackoff,
+		idleInterval: spoolIdleInterval,
+		logger:       loggerOrDiscard(nil),
+		wake:         make(chan struct{}, 1),
+	}, nil
+}
+
+// SetLogger sends the spool's records to logger. Call it before replaying.
+func (spool *AutocutSpool) SetLogger(logger *slog.Logger) {
+	spool.logger = loggerOrDiscard(logger)
+}
+
+// spoolable reports whether a failed create is worth replaying later. Validation and auth
+// failures will fail the same way again, so they are not.
+func spoolable(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests
+}
+
+// Store writes createRequest to the spool unless an autocut with the same fingerprint is already
+// waiting, so an outage does not pile up copies of a repeating autocut to be replayed one by one.
+// Files are written under a temporary name and renamed so a crash never leaves a partial record behind.
+func (spool *AutocutSpool) Store(createRequest ticket_model_request.TicketModelCreateRequest) error {
+	// The fingerprint is part of the file name so pending duplicates are found without reading records.
+	fingerprint := AutocutFingerprint(createRequest.Title, createRequest.Description, createRequest.Severity,
+		createRequest.TeamRangeKey)[:spoolFingerprintLength]
+	spool.mu.Lock()
+	defer spool.mu.Unlock()
+	names, err := spool.list()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "-"+fingerprint+spoolFileExtension) {
+			spool.logger.Info("DUPLICATE", "method", "AutocutSpool.Store", "file", name)
+			return nil
+		}
+	}
+	now := spool.now()
+	name := fmt.Sprintf("%019d-%s%s", now.UnixNano(), fingerprint, spoolFileExtension)
+	if err := spool.writeFile(name, spoolRecord{Request: createRequest, SpooledAt: now.UTC().Format(time.RFC3339Nano)}); err != nil {
+		return err
+	}
+	select {
+	case spool.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Pending returns the number of records waiting to be replayed.
+func (spool *AutocutSpool) Pending() (int, error) {
+	names, err := spool.list()
+	return len(names), err
+}
+
+func (spool *AutocutSpool) write(name string, record spoolRecord) error {
+	spool.mu.Lock()
+	defer spool.mu.Unlock()
+	return spool.writeFile(name, record)
+}
+
+// writeFile replaces the record stored under name. Callers must hold spool.mu.
+func (spool *AutocutSpool) writeFile(name string, record spoolRecord) error {
+	data, err := json2.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(spool.dir, spoolTempFilePrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), filepath.Join(spool.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// list returns record file names oldest first.
+func (spool *AutocutSpool) list() ([]string, error) {
+	entries, err := os.ReadDir(spool.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, spoolFileExtension) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Replay sends spooled records oldest first until one fails with a spoolable status, which means
+// the service is still unavailable. Records that succeed, or fail in a way a retry cannot fix, are
+// removed; a record whose send is cut short by ctx is kept. It returns the number of records still waiting.
+func (spool *AutocutSpool) Replay(
+	ctx context.Context,
+	send func(context.Context, ticket_model_request.TicketModelCreateRequest) int,
+) (int, error) {
+	names, err := spool.list()
+	if err != nil {
+		return 0, err
+	}
+	for index, name := range names {
+		if ctx.Err() != nil {
+			return len(names) - index, ctx.Err()
+		}
+		path := filepath.Join(spool.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return len(names) - index, err
+		}
+		var record spoolRecord
+		if err := json2.Unmarshal(data, &record); err != nil {
+			spool.logger.ErrorContext(ctx, "PARSE_ERROR", "method", "AutocutSpool.Replay", "file", name, "error", err)
+			_ = os.Remove(path)
+			continue
+		}
+		statusCode := send(ctx, record.Request)
+		if ctx.Err() != nil || statusCode == 499 {
+			// The send was cut short rather than answered, so keep the record as it is for the next run.
+			return len(names) - index, ctx.Err()
+		}
+		if statusCode != http.StatusOK && spoolable(statusCode) {
+			record.Attempts++
+			if err := spool.write(name, record); err != nil {
+				spool.logger.ErrorContext(ctx, "WRITE_ERROR", "method", "AutocutSpool.Replay", "file", name, "error", err)
+			}
+			return len(names) - index, nil
+		}
+		if statusCode != http.StatusOK {
+			spool.logger.WarnContext(ctx, "REJECTED", "method", "AutocutSpool.Replay",
+				"file", name, "status_code", statusCode, "attempts", record.Attempts+1)
+		}
+		if err := os.Remove(path); err != nil {
+			return len(names) - index - 1, err
+		}
+	}
+	return 0, nil
+}
+
+// Run replays the spool until ctx is done, backing off exponentially while the service keeps
+// failing and otherwise checking again whenever a record is stored or the idle interval passes.
+func (spool *AutocutSpool) Run(
+	ctx context.Context,
+	send func(context.Context, ticket_model_request.TicketModelCreateRequest) int,
+) {
+	backoff := spool.minBackoff
+	for {
+		remaining, err := spool.Replay(ctx, send)
+		if err != nil && ctx.Err() == nil {
+			spool.logger.ErrorContext(ctx, "REPLAY_ERROR", "method", "AutocutSpool.Run", "remaining", remaining, "error", err)
+		}
+		wait := spool.idleInterval
+		wake := spool.wake
+		if remaining > 0 || err != nil {
+			wait = backoff
+			backoff = min(backoff*2, spool.maxBackoff)
+			wake = nil
+		} else {
+			backoff = spool.minBackoff
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-wake:
+			timer.Stop()
+		}
+	}
+}
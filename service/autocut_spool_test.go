@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	json2 "encoding/json"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestSpool opens a spool on dir whose records are ordered by a clock that ticks on every Store.
+func newTestSpool(t *testing.T, dir string) *AutocutSpool {
+	t.Helper()
+	spool, err := NewAutocutSpool(dir)
+	if err != nil {
+		t.Fatalf("NewAutocutSpool: %v", err)
+	}
+	clock := &fakeClock{at: time.Unix(0, 0)}
+	spool.now = func() time.Time {
+		clock.advance(time.Millisecond)
+		return clock.now()
+	}
+	return spool
+}
+
+func TestAutocutSpoolReplayAfterRestart(t *testing.T) {
+	tests := []struct {
+		name string
+		// statusCodes answers the sends in order; a send past the end answers 200.
+		statusCodes   []int
+		wantSent      []string
+		wantRemaining int
+		// wantLeft is what a second replay, after another restart, sends.
+		wantLeft []string
+	}{
+		{
+			name:     "all succeed",
+			wantSent: []string{"first", "second", "third"},
+		},
+		{
+			name:          "stops at the first spoolable failure",
+			statusCodes:   []int{http.StatusOK, http.StatusServiceUnavailable},
+			wantSent:      []string{"first", "second"},
+			wantRemaining: 2,
+			wantLeft:      []string{"second", "third"},
+		},
+		{
+			name:        "drops records the service rejects",
+			statusCodes: []int{http.StatusBadRequest, http.StatusUnauthorized},
+			wantSent:    []string{"first", "second", "third"},
+		},
+		{
+			name:          "keeps a record whose send was cut short",
+			statusCodes:   []int{http.StatusOK, 499},
+			wantSent:      []string{"first", "second"},
+			wantRemaining: 2,
+			wantLeft:      []string{"second", "third"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			stored := newTestSpool(t, dir)
+			for _, title := range []string{"first", "second", "third"} {
+				if err := stored.Store(ticket_model_request.TicketModelCreateRequest{Title: title}); err != nil {
+					t.Fatalf("Store: %v", err)
+				}
+			}
+			var sent []string
+			send := func(_ context.Context, createRequest ticket_model_request.TicketModelCreateRequest) int {
+				sent = append(sent, createRequest.Title)
+				if len(sent) <= len(test.statusCodes) {
+					return test.statusCodes[len(sent)-1]
+				}
+				return http.StatusOK
+			}
+			remaining, err := newTestSpool(t, dir).Replay(context.Background(), send)
+			if err != nil {
+				t.Fatalf("Replay: %v", err)
+			}
+			if remaining != test.wantRemaining || !reflect.DeepEqual(sent, test.wantSent) {
+				t.Fatalf("Replay sent %v leaving %d, want %v leaving %d", sent, remaining, test.wantSent, test.wantRemaining)
+			}
+			sent, test.statusCodes = nil, nil
+			if _, err := newTestSpool(t, dir).Replay(context.Background(), send); err != nil {
+				t.Fatalf("second Replay: %v", err)
+			}
+			if !reflect.DeepEqual(sent, test.wantLeft) {
+				t.Errorf("second Replay sent %v, want %v", sent, test.wantLeft)
+			}
+		})
+	}
+}
+
+func TestAutocutSpoolStoresAnAutocutOnce(t *testing.T) {
+	spool := newTestSpool(t, t.TempDir())
+	diskFull := ticket_model_request.TicketModelCreateRequest{Title: "Disk full", Description: "host 1", Severity: 3, TeamRangeKey: "team"}
+	store := func(createRequests ...ticket_model_request.TicketModelCreateRequest) {
+		t.Helper()
+		for _, createRequest := range createRequests {
+			if err := spool.Store(createRequest); err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+		}
+	}
+	// The fingerprint ignores digits, so both hosts count as the same autocut.
+	repeat := diskFull
+	repeat.Description = "host 2"
+	otherTeam := diskFull
+	otherTeam.TeamRangeKey = "other"
+	store(diskFull, repeat, otherTeam)
+	if pending, _ := spool.Pending(); pending != 2 {
+		t.Fatalf("Pending = %d, want 2", pending)
+	}
+	if _, err := spool.Replay(context.Background(), func(context.Context, ticket_model_request.TicketModelCreateRequest) int {
+		return http.StatusOK
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	// Once replayed, the autocut can be spooled again.
+	store(diskFull)
+	if pending, _ := spool.Pending(); pending != 1 {
+		t.Errorf("Pending = %d after storing a replayed autocut again, want 1", pending)
+	}
+}
+
+func TestAutocutSpoolReplayKeepsRecordOnCancel(t *testing.T) {
+	spool := newTestSpool(t, t.TempDir())
+	if err := spool.Store(ticket_model_request.TicketModelCreateRequest{Title: "title"}); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	remaining, err := spool.Replay(ctx, func(context.Context, ticket_model_request.TicketModelCreateRequest) int {
+		cancel()
+		return http.StatusOK
+	})
+	if err != context.Canceled || remaining != 1 {
+		t.Fatalf("Replay = %d, %v; want 1, %v", remaining, err, context.Canceled)
+	}
+	if pending, _ := spool.Pending(); pending != 1 {
+		t.Errorf("Pending = %d after a cancelled replay, want 1", pending)
+	}
+}
+
+func TestAutocutSpoolCountsAttempts(t *testing.T) {
+	dir := t.TempDir()
+	spool := newTestSpool(t, dir)
+	if err := spool.Store(ticket_model_request.TicketModelCreateRequest{Title: "title"}); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	unavailable := func(context.Context, ticket_model_request.TicketModelCreateRequest) int {
+		return http.StatusServiceUnavailable
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, err := spool.Replay(context.Background(), unavailable); err != nil {
+			t.Fatalf("Replay: %v", err)
+		}
+	}
+	names, err := spool.list()
+	if err != nil || len(names) != 1 {
+		t.Fatalf("list = %v, %v; want one record", names, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, names[0]))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var record spoolRecord
+	if err := json2.Unmarshal(data, &record); err != nil || record.Attempts != 2 {
+		t.Errorf("record = %+v, %v; want 2 attempts", record, err)
+	}
+}
+
+func TestAutocutSpoolDropsUnreadableRecords(t *testing.T) {
+	dir := t.TempDir()
+	spool := newTestSpool(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "0-broken"+spoolFileExtension), []byte("{"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, spoolTempFilePrefix+"partial"), []byte("{"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	remaining, err := spool.Replay(context.Background(), func(context.Context, ticket_model_request.TicketModelCreateRequest) int {
+		t.Error("sent an unreadable record")
+		return http.StatusOK
+	})
+	if err != nil || remaining != 0 {
+		t.Fatalf("Replay = %d, %v; want 0, nil", remaining, err)
+	}
+	if pending, _ := spool.Pending(); pending != 0 {
+		t.Errorf("Pending = %d, want 0", pending)
+	}
+}
+
+func TestSpoolable(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{statusCode: http.StatusBadRequest},
+		{statusCode: http.StatusUnauthorized},
+		{statusCode: http.StatusConflict},
+		{statusCode: http.StatusRequestTimeout, want: true},
+		{statusCode: http.StatusTooManyRequests, want: true},
+		{statusCode: http.StatusInternalServerError, want: true},
+		{statusCode: http.StatusServiceUnavailable, want: true},
+	}
+	for _, test := range tests {
+		if got := spoolable(test.statusCode); got != test.want {
+			t.Errorf("spoolable(%d) = %t, want %t", test.statusCode, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"container/list"
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheConfig bounds the read-through caches put in front of the Fetch methods. Zero fields take the
+// values from DefaultCacheConfig.
+type CacheConfig struct {
+	// TTL is how long a fetched value is served before it is fetched again.
+	TTL time.Duration
+	// MaxEntries bounds each cache; the least recently used entry is evicted to make room.
+	MaxEntries int
+}
+
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		TTL:        time.Minute,
+		MaxEntries: 1000,
+	}
+}
+
+// CacheStats counts what a cache has done since it was created.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Entries   int
+}
+
+// cacheKey identifies a cached value: the row it was read from and who read it, since the service
+// may answer the same row differently per user.
+type cacheKey struct {
+	row    TicketKey
+	caller string
+}
+
+type cacheEntry[T any] struct {
+	key     cacheKey
+	value   T
+	expires time.Time
+}
+
+// readCache is a TTL and size bounded LRU cache of fetched rows. Invalidating a row drops the values
+// cached for every caller.
+type readCache[T any] struct {
+	config CacheConfig
+	name   string
+	// lookups is nil unless the metrics manager counts cache lookups.
+	lookups CacheLookupSender
+	now     func() time.Time
+	mu      sync.Mutex
+	// order holds *cacheEntry[T], most recently used first.
+	order *list.List
+	rows  map[TicketKey]map[string]*list.Element
+	// generation changes on every invalidation so a fetch that raced a write does not store the
+	// value it read before the write.
+	generation uint64
+	stats      CacheStats
+}
+
+// newReadCache reports hits and misses under name when metricsManager is a CacheLookupSender.
+func newReadCache[T any](config CacheConfig, metricsManager metrics.MetricsManagerContract, name string) *readCache[T] {
+	defaults := DefaultCacheConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaults.MaxEntries
+	}
+	lookups, _ := metricsManager.(CacheLookupSender)
+	return &readCache[T]{
+		config:  config,
+		name:    name,
+		lookups: lookups,
+		now:     time.Now,
+		order:   list.New(),
+		rows:    map[TicketKey]map[string]*list.Element{},
+	}
+}
+
+// get returns a copy of the cached value for key. On a miss it returns the generation to hand to put
+// once the value has been fetched.
+func (cache *readCache[T]) get(key cacheKey) (*T, uint64) {
+	value, generation := cache.lookup(key)
+	if cache.lookups != nil {
+		cache.lookups.SendCacheLookup(cache.name, value != nil)
+	}
+	return value, generation
+}
+
+func (cache *readCache[T]) lookup(key cacheKey) (*T, uint64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if element, ok := cache.rows[key.row][key.caller]; ok {
+		entry := element.Value.(*cacheEntry[T])
+		if cache.now().Before(entry.expires) {
+			cache.order.MoveToFront(element)
+			cache.stats.Hits++
+			value := entry.value
+			return &value, cache.generation
+		}
+		cache.remove(element)
+	}
+	cache.stats.Misses++
+	return nil, cache.generation
+}
+
+// put stores a copy of value unless the cache was invalidated since generation was handed out.
+func (cache *readCache[T]) put(key cacheKey, value *T, generation uint64) {
+	if value == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if generation != cache.generation {
+		return
+	}
+	entry := &cacheEntry[T]{key: key, value: *value, expires: cache.now().Add(cache.config.TTL)}
+	if element, ok := cache.rows[key.row][key.caller]; ok {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+		return
+	}
+	for cache.order.Len() >= cache.config.MaxEntries {
+		cache.remove(cache.order.Back())
+		cache.stats.Evictions++
+	}
+	callers, ok := cache.rows[key.row]
+	if !ok {
+		callers = map[string]*list.Element{}
+		cache.rows[key.row] = callers
+	}
+	callers[key.caller] = cache.order.PushFront(entry)
+}
+
+// invalidate drops every value cached for row.
+func (cache *readCache[T]) invalidate(row TicketKey) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.generation++
+	for _, element := range cache.rows[row] {
+		cache.order.Remove(element)
+	}
+	delete(cache.rows, row)
+}
+
+func (cache *readCache[T]) Stats() CacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	stats := cache.stats
+	stats.Entries = cache.order.Len()
+	return stats
+}
+
+// remove must be called with cache.mu held.
+func (cache *readCache[T]) remove(element *list.Element) {
+	entry := cache.order.Remove(element).(*cacheEntry[T])
+	callers := cache.rows[entry.key.row]
+	delete(callers, entry.key.caller)
+	if len(callers) == 0 {
+		delete(cache.rows, entry.key.row)
+	}
+}
+
+// fetch serves key from the cache, falling back to fetch and caching what it returns on success.
+func (cache *readCache[T]) fetch(key cacheKey, fetch func() response.Response[T]) response.Response[T] {
+	value, generation := cache.get(key)
+	if value != nil {
+		return response.Response[T]{Data: value, StatusCode: http.StatusOK}
+	}
+	fetched := fetch()
+	if fetched.StatusCode == http.StatusOK {
+		cache.put(key, fetched.Data, generation)
+	}
+	return fetched
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func cacheKeyOf(partitionKey string, rangeKey string) cacheKey {
+	return cacheKey{row: TicketKey{PartitionKey: partitionKey, RangeKey: rangeKey}}
+}
+
+func newTestCache(config CacheConfig, clock *fakeClock) *readCache[string] {
+	cache := newReadCache[string](config, &recordingMetrics{}, "test")
+	cache.now = clock.now
+	return cache
+}
+
+// fill puts value under key with the generation a miss hands out.
+func fill(cache *readCache[string], key cacheKey, value string) {
+	_, generation := cache.lookup(key)
+	cache.put(key, &value, generation)
+}
+
+func TestReadCache(t *testing.T) {
+	a, b, c := cacheKeyOf("pk", "a"), cacheKeyOf("pk", "b"), cacheKeyOf("pk", "c")
+	aForUser := cacheKey{row: a.row, caller: "user"}
+	tests := []struct {
+		name   string
+		config CacheConfig
+		// run acts on the cache before the lookups of wantHits and wantMiss, which count in wantStats.
+		run       func(cache *readCache[string], clock *fakeClock)
+		wantHits  []cacheKey
+		wantMiss  []cacheKey
+		wantStats CacheStats
+	}{
+		{
+			name:   "serves what was put",
+			config: CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			run: func(cache *readCache[string], _ *fakeClock) {
+				fill(cache, a, "a")
+			},
+			wantHits:  []cacheKey{a},
+			wantMiss:  []cacheKey{b},
+			wantStats: CacheStats{Hits: 1, Misses: 2, Entries: 1},
+		},
+		{
+			name:   "expires after the TTL",
+			config: CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			run: func(cache *readCache[string], clock *fakeClock) {
+				fill(cache, a, "a")
+				clock.advance(59 * time.Second)
+				fill(cache, b, "b")
+				clock.advance(time.Second)
+			},
+			wantHits:  []cacheKey{b},
+			wantMiss:  []cacheKey{a},
+			wantStats: CacheStats{Hits: 1, Misses: 3, Entries: 1},
+		},
+		{
+			name:   "evicts the least recently used",
+			config: CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			run: func(cache *readCache[string], _ *fakeClock) {
+				fill(cache, a, "a")
+				fill(cache, b, "b")
+				cache.lookup(a)
+				fill(cache, c, "c")
+			},
+			wantHits:  []cacheKey{a, c},
+			wantMiss:  []cacheKey{b},
+			wantStats: CacheStats{Hits: 3, Misses: 4, Evictions: 1, Entries: 2},
+		},
+		{
+			name:   "invalidation drops every caller of the row",
+			config: CacheConfig{TTL: time.Minute, MaxEntries: 3},
+			run: func(cache *readCache[string], _ *fakeClock) {
+				fill(cache, a, "a")
+				fill(cache, aForUser, "a")
+				fill(cache, b, "b")
+				cache.invalidate(a.row)
+			},
+			wantHits:  []cacheKey{b},
+			wantMiss:  []cacheKey{a, aForUser},
+			wantStats: CacheStats{Hits: 1, Misses: 5, Entries: 1},
+		},
+		{
+			name:   "a fetch that raced an invalidation is not stored",
+			config: CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			run: func(cache *readCache[string], _ *fakeClock) {
+				_, generation := cache.lookup(a)
+				cache.invalidate(b.row)
+				value := "stale"
+				cache.put(a, &value, generation)
+			},
+			wantMiss:  []cacheKey{a},
+			wantStats: CacheStats{Misses: 2},
+		},
+		{
+			name:   "zero config takes the default TTL",
+			config: CacheConfig{},
+			run: func(cache *readCache[string], clock *fakeClock) {
+				fill(cache, a, "a")
+				clock.advance(DefaultCacheConfig().TTL - time.Nanosecond)
+			},
+			wantHits:  []cacheKey{a},
+			wantStats: CacheStats{Hits: 1, Misses: 1, Entries: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &fakeClock{at: time.Unix(0, 0)}
+			cache := newTestCache(test.config, clock)
+			test.run(cache, clock)
+			for _, key := range test.wantHits {
+				if value, _ := cache.lookup(key); value == nil {
+					t.Errorf("%+v missed, want a hit", key)
+				}
+			}
+			for _, key := range test.wantMiss {
+				if value, _ := cache.lookup(key); value != nil {
+					t.Errorf("%+v hit %q, want a miss", key, *value)
+				}
+			}
+			if stats := cache.Stats(); stats != test.wantStats {
+				t.Errorf("stats = %+v, want %+v", stats, test.wantStats)
+			}
+		})
+	}
+}
+
+func TestReadCacheFetch(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		wantFetches int
+	}{
+		{name: "caches a success", statusCode: http.StatusOK, wantFetches: 1},
+		{name: "does not cache a failure", statusCode: http.StatusNotFound, wantFetches: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newTestCache(CacheConfig{}, &fakeClock{at: time.Unix(0, 0)})
+			fetches := 0
+			fetch := func() response.Response[string] {
+				fetches++
+				value := "fetched"
+				return response.Response[string]{Data: &value, StatusCode: test.statusCode}
+			}
+			for attempt := 0; attempt < 2; attempt++ {
+				fetched := cache.fetch(cacheKeyOf("pk", "rk"), fetch)
+				if fetched.StatusCode != test.statusCode || fetched.Data == nil || *fetched.Data != "fetched" {
+					t.Fatalf("fetch = %+v, want %d with the fetched value", fetched, test.statusCode)
+				}
+			}
+			if fetches != test.wantFetches {
+				t.Errorf("fetched %d times, want %d", fetches, test.wantFetches)
+			}
+		})
+	}
+}
+
+func TestReadCacheReportsLookups(t *testing.T) {
+	gauge := &gaugeMetrics{}
+	cache := newReadCache[string](CacheConfig{}, gauge, "ticket")
+	key := cacheKeyOf("pk", "rk")
+	_, generation := cache.get(key)
+	value := "value"
+	cache.put(key, &value, generation)
+	cache.get(key)
+	if want := []bool{false, true}; !reflect.DeepEqual(gauge.lookups["ticket"], want) {
+		t.Errorf("lookups = %v, want %v", gauge.lookups, want)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+)
+
+// CachedTicketService serves TicketService.Fetch from a read-through cache in front of another
+// TicketServiceContract. Its own Update and Delete calls drop the cached ticket whatever their outcome;
+// writes made elsewhere are seen once the TTL passes.
+type CachedTicketService struct {
+	TicketServiceContract
+	cache *readCache[model.TicketModel]
+}
+
+var _ TicketServiceContract = (*CachedTicketService)(nil)
+
+func NewCachedTicketService(
+	ticketService TicketServiceContract,
+	config CacheConfig,
+	metricsManager metrics.MetricsManagerContract,
+) *CachedTicketService {
+	return &CachedTicketService{
+		TicketServiceContract: ticketService,
+		cache:                 newReadCache[model.TicketModel](config, metricsManager, "ticket"),
+	}
+}
+
+func (cached *CachedTicketService) CacheStats() CacheStats {
+	return cached.cache.Stats()
+}
+
+func (cached *CachedTicketService) Fetch(partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	return cached.FetchCtx(context.Background(), partitionKey, rangeKey)
+}
+
+func (cached *CachedTicketService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	key := cacheKey{row: TicketKey{PartitionKey: partitionKey, RangeKey: rangeKey}}
+	return cached.cache.fetch(key, func() response.Response[model.TicketModel] {
+		return cached.TicketServiceContract.FetchCtx(ctx, partitionKey, rangeKey)
+	})
+}
+
+func (cached *CachedTicketService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error) {
+	return result(cached.FetchCtx(ctx, partitionKey, rangeKey))
+}
+
+func (cached *CachedTicketService) FetchMany(keys []TicketKey) FetchManyResult {
+	return cached.FetchManyCtx(context.Background(), keys)
+}
+
+// FetchManyCtx serves what it can from the cache and hands the rest to the wrapped FetchMany.
+func (cached *CachedTicketService) FetchManyCtx(ctx context.Context, keys []TicketKey) FetchManyResult {
+	fetched := FetchManyResult{Tickets: map[TicketKey]*model.TicketModel{}, Errors: map[TicketKey]error{}}
+	generations := map[TicketKey]uint64{}
+	var missing []TicketKey
+	for _, key := range keys {
+		if _, seen := generations[key]; seen {
+			continue
+		}
+		if _, seen := fetched.Tickets[key]; seen {
+			continue
+		}
+		ticket, generation := cached.cache.get(cacheKey{row: key})
+		if ticket != nil {
+			fetched.Tickets[key] = ticket
+			continue
+		}
+		generations[key] = generation
+		missing = append(missing, key)
+	}
+	if len(missing) == 0 {
+		return fetched
+	}
+	remaining := cached.TicketServiceContract.FetchManyCtx(ctx, missing)
+	for key, ticket := range remaining.Tickets {
+		cached.cache.put(cacheKey{row: key}, ticket, generations[key])
+		fetched.Tickets[key] = ticket
+	}
+	for key, err := range remaining.Errors {
+		fetched.Errors[key] = err
+	}
+	return fetched
+}
+
+func (cached *CachedTicketService) Update(userId string, ticketModel model.TicketModel) response.Response[bool] {
+	return cached.UpdateCtx(context.Background(), userId, ticketModel)
+}
+
+func (cached *CachedTicketService) UpdateCtx(ctx context.Context, userId string, ticketModel model.TicketModel) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: ticketModel.PartitionKey, RangeKey: ticketModel.RangeKey})
+	return cached.TicketServiceContract.UpdateCtx(ctx, userId, ticketModel)
+}
+
+func (cached *CachedTicketService) UpdateWithError(ctx context.Context, userId string, ticketModel model.TicketModel) error {
+	return ResponseError(cached.UpdateCtx(ctx, userId, ticketModel))
+}
+
+func (cached *CachedTicketService) Delete(deleteRequest model.DeleteRequest) response.Response[bool] {
+	return cached.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (cached *CachedTicketService) DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: deleteRequest.PartitionKey, RangeKey: deleteRequest.RangeKey})
+	return cached.TicketServiceContract.DeleteCtx(ctx, deleteRequest)
+}
+
+func (cached *CachedTicketService) DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error {
+	return ResponseError(cached.DeleteCtx(ctx, deleteRequest))
+}
+
+func (cached *CachedTicketService) Transition(ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	return cached.TransitionCtx(context.Background(), ticketKey, toStatus, actor, reason)
+}
+
+func (cached *CachedTicketService) TransitionCtx(ctx context.Context, ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	_, err := cached.TransitionWithError(ctx, ticketKey, toStatus, actor, reason)
+	return err == nil
+}
+
+func (cached *CachedTicketService) TransitionWithError(
+	ctx context.Context,
+	ticketKey TicketKey,
+	toStatus model.TicketStatus,
+	actor string,
+	reason string,
+) (*model.TicketModel, error) {
+	defer cached.cache.invalidate(ticketKey)
+	return cached.TicketServiceContract.TransitionWithError(ctx, ticketKey, toStatus, actor, reason)
+}
+
+// CachedTicketTeamService serves TicketTeamService.Fetch from a read-through cache, per user, in front
+// of another TicketTeamServiceContract. Its own Update and Delete calls drop the cached team.
+type CachedTicketTeamService struct {
+	TicketTeamServiceContract
+	cache *readCache[model.TicketTeamModel]
+}
+
+var _ TicketTeamServiceContract = (*CachedTicketTeamService)(nil)
+
+func NewCachedTicketTeamService(
+	teamService TicketTeamServiceContract,
+	config CacheConfig,
+	metricsManager metrics.MetricsManagerContract,
+) *CachedTicketTeamService {
+	return &CachedTicketTeamService{
+		TicketTeamServiceContract: teamService,
+		cache:                     newReadCache[model.TicketTeamModel](config, metricsManager, "team"),
+	}
+}
+
+func (cached *CachedTicketTeamService) CacheStats() CacheStats {
+	return cached.cache.Stats()
+}
+
+func (cached *CachedTicketTeamService) Fetch(partitionKey string, rangeKey string, userId string) response.Response[model.TicketTeamModel] {
+	return cached.FetchCtx(context.Background(), partitionKey, rangeKey, userId)
+}
+
+func (cached *CachedTicketTeamService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string, userId string) response.Response[model.TicketTeamModel] {
+	key := cacheKey{row: TicketKey{PartitionKey: partitionKey, RangeKey: rangeKey}, caller: userId}
+	return cached.cache.fetch(key, func() response.Response[model.TicketTeamModel] {
+		return cached.TicketTeamServiceContract.FetchCtx(ctx, partitionKey, rangeKey, userId)
+	})
+}
+
+func (cached *CachedTicketTeamService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string, userId string) (*model.TicketTeamModel, error) {
+	return result(cached.FetchCtx(ctx, partitionKey, rangeKey, userId))
+}
+
+func (cached *CachedTicketTeamService) Update(userId string, teamModel model.TicketTeamModel) response.Response[bool] {
+	return cached.UpdateCtx(context.Background(), userId, teamModel)
+}
+
+func (cached *CachedTicketTeamService) UpdateCtx(ctx context.Context, userId string, teamModel model.TicketTeamModel) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: teamModel.PartitionKey, RangeKey: teamModel.RangeKey})
+	return cached.TicketTeamServiceContract.UpdateCtx(ctx, userId, teamModel)
+}
+
+func (cached *CachedTicketTeamService) UpdateWithError(ctx context.Context, userId string, teamModel model.TicketTeamModel) error {
+	return ResponseError(cached.UpdateCtx(ctx, userId, teamModel))
+}
+
+func (cached *CachedTicketTeamService) Delete(deleteRequest model.DeleteRequest) response.Response[bool] {
+	return cached.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (cached *CachedTicketTeamService) DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: deleteRequest.PartitionKey, RangeKey: deleteRequest.RangeKey})
+	return cached.TicketTeamServiceContract.DeleteCtx(ctx, deleteRequest)
+}
+
+func (cached *CachedTicketTeamService) DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error {
+	return ResponseError(cached.DeleteCtx(ctx, deleteRequest))
+}
+
+// CachedTicketTeamMemberService serves TicketTeamMemberService.Fetch from a read-through cache, per
+// email, in front of another TicketTeamMemberServiceContract. Its own Update and Delete calls drop the
+// cached member.
+type CachedTicketTeamMemberService struct {
+	TicketTeamMemberServiceContract
+	cache *readCache[model.TicketTeamMemberModel]
+}
+
+var _ TicketTeamMemberServiceContract = (*CachedTicketTeamMemberService)(nil)
+
+func NewCachedTicketTeamMemberService(
+	memberService TicketTeamMemberServiceContract,
+	config CacheConfig,
+	metricsManager metrics.MetricsManagerContract,
+) *CachedTicketTeamMemberService {
+	return &CachedTicketTeamMemberService{
+		TicketTeamMemberServiceContract: memberService,
+		cache:                           newReadCache[model.TicketTeamMemberModel](config, metricsManager, "member"),
+	}
+}
+
+func (cached *CachedTicketTeamMemberService) CacheStats() CacheStats {
+	return cached.cache.Stats()
+}
+
+func (cached *CachedTicketTeamMemberService) Fetch(email string, partitionKey string, rangeKey string) response.Response[model.TicketTeamMemberModel] {
+	return cached.FetchCtx(context.Background(), email, partitionKey, rangeKey)
+}
+
+func (cached *CachedTicketTeamMemberService) FetchCtx(ctx context.Context, email string, partitionKey string, rangeKey string) response.Response[model.TicketTeamMemberModel] {
+	key := cacheKey{row: TicketKey{PartitionKey: partitionKey, RangeKey: rangeKey}, caller: email}
+	return cached.cache.fetch(key, func() response.Response[model.TicketTeamMemberModel] {
+		return cached.TicketTeamMemberServiceContract.FetchCtx(ctx, email, partitionKey, rangeKey)
+	})
+}
+
+func (cached *CachedTicketTeamMemberService) FetchWithError(ctx context.Context, email string, partitionKey string, rangeKey string) (*model.TicketTeamMemberModel, error) {
+	return result(cached.FetchCtx(ctx, email, partitionKey, rangeKey))
+}
+
+func (cached *CachedTicketTeamMemberService) Update(userId string, memberModel model.TicketTeamMemberModel) response.Response[bool] {
+	return cached.UpdateCtx(context.Background(), userId, memberModel)
+}
+
+func (cached *CachedTicketTeamMemberService) UpdateCtx(ctx context.Context, userId string, memberModel model.TicketTeamMemberModel) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: memberModel.PartitionKey, RangeKey: memberModel.RangeKey})
+	return cached.TicketTeamMemberServiceContract.UpdateCtx(ctx, userId, memberModel)
+}
+
+func (cached *CachedTicketTeamMemberService) UpdateWithError(ctx context.Context, userId string, memberModel model.TicketTeamMemberModel) error {
+	return ResponseError(cached.UpdateCtx(ctx, userId, memberModel))
+}
+
+func (cached *CachedTicketTeamMemberService) Delete(deleteRequest model.DeleteRequest) response.Response[bool] {
+	return cached.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (cached *CachedTicketTeamMemberService) DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool] {
+	defer cached.cache.invalidate(TicketKey{PartitionKey: deleteRequest.PartitionKey, RangeKey: deleteRequest.RangeKey})
+	return cached.TicketTeamMemberServiceContract.DeleteCtx(ctx, deleteRequest)
+}
+
+func (cached *CachedTicketTeamMemberService) DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error {
+	return ResponseError(cached.DeleteCtx(ctx, deleteRequest))
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the service while the circuit breaker is open.
+// It also matches ErrUnavailable through the *RequestError it is wrapped in.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+// circuitBreakerMetric is the call name breaker metrics are sent under.
+const circuitBreakerMetric = "CincinnatiTicketService.circuitBreaker"
+
+func (state CircuitState) String() string {
+	switch state {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(state))
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before letting a probe through.
+	OpenTimeout time.Duration
+	// HalfOpenSuccesses is the number of successful probes, sent one at a time, that close it again.
+	HalfOpenSuccesses int
+}
+
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold:  5,
+		OpenTimeout:       30 * time.Second,
+		HalfOpenSuccesses: 1,
+	}
+}
+
+// callOutcome is what an attempt tells the breaker about the health of the service.
+type callOutcome int
+
+const (
+	outcomeSuccess callOutcome = iota
+	outcomeFailure
+	// outcomeIgnored says nothing about the service, e.g. the caller cancelled.
+	outcomeIgnored
+)
+
+// outcomeOf classifies an attempt. Transport errors, deadlines and 5xx mean the service is
+// struggling; any other answer, 4xx included, shows it is up.
+func outcomeOf(statusCode int, err error) callOutcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case errors.Is(err, context.Canceled):
+		return outcomeIgnored
+	case statusCode == 0 || statusCode >= http.StatusInternalServerError || errors.Is(err, context.DeadlineExceeded):
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
+
+// CircuitBreaker stops calls to the service after repeated failures so callers fail fast instead of
+// waiting on timeouts. One breaker is meant to be shared by every service talking to the same endpoint.
+type CircuitBreaker struct {
+	config         CircuitBreakerConfig
+	metricsManager metrics.MetricsManagerContract
+	logger         *slog.Logger
+	now            func() time.Time
+	mu             sync.Mutex
+	state          CircuitState
+	failures       int
+	openedAt       time.Time
+	probing        bool
+	probeSuccesses int
+}
+
+// NewCircuitBreaker reports every transition to metricsManager. A metricsManager implementing
+// CircuitStateSender is also told the initial closed state.
+func NewCircuitBreaker(config CircuitBreakerConfig, metricsManager metrics.MetricsManagerContract) *CircuitBreaker {
+	if sender, ok := metricsManager.(CircuitStateSender); ok {
+		sender.SendCircuitState(circuitBreakerMetric, int(CircuitClosed))
+	}
+	return &CircuitBreaker{
+		config:         config,
+		metricsManager: metricsManager,
+		logger:         loggerOrDiscard(nil),
+		now:            time.Now,
+	}
+}
+
+// SetLogger sends state transitions to logger. Call it before the breaker is in use.
+func (breaker *CircuitBreaker) SetLogger(logger *slog.Logger) {
+	breaker.logger = loggerOrDiscard(logger)
+}
+
+func (breaker *CircuitBreaker) State() CircuitState {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	return breaker.state
+}
+
+// allow reports whether an attempt may go out and whether it is the probe. Once the open timeout
+// has passed, a single probe is let through at a time; its outcome decides whether the circuit
+// closes or opens again.
+func (breaker *CircuitBreaker) allow() (bool, bool) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	switch breaker.state {
+	case CircuitOpen:
+		if breaker.now().Sub(breaker.openedAt) < breaker.config.OpenTimeout {
+			return false, false
+		}
+		breaker.transition(CircuitHalfOpen)
+		breaker.probing = true
+		return true, true
+	case CircuitHalfOpen:
+		if breaker.probing {
+			return false, false
+		}
+		breaker.probing = true
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// record takes the outcome of an attempt allowed by allow. While half-open only the probe counts;
+// attempts that started before the circuit opened no longer say anything new.
+func (breaker *CircuitBreaker) record(outcome callOutcome, probe bool) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if breaker.state == CircuitHalfOpen {
+		if !probe {
+			return
+		}
+		breaker.probing = false
+		switch outcome {
+		case outcomeSuccess:
+			breaker.probeSuccesses++
+			if breaker.probeSuccesses >= max(breaker.config.HalfOpenSuccesses, 1) {
+				breaker.failures = 0
+				breaker.transition(CircuitClosed)
+			}
+		case outcomeFailure:
+			breaker.transition(CircuitOpen)
+		}
+		return
+	}
+	switch outcome {
+	case outcomeSuccess:
+		breaker.failures = 0
+	case outcomeFailure:
+		breaker.failures++
+		if breaker.state == CircuitClosed && breaker.failures >= max(breaker.config.FailureThreshold, 1) {
+			breaker.transition(CircuitOpen)
+		}
+	}
+}
+
+// transition must be called with breaker.mu held.
+func (breaker *CircuitBreaker) transition(state CircuitState) {
+	previous := breaker.state
+	breaker.state = state
+	breaker.probeSuccesses = 0
+	if state == CircuitOpen {
+		breaker.openedAt = breaker.now()
+	}
+	level := slog.LevelInfo
+	if state == CircuitOpen {
+		level = slog.LevelWarn
+	}
+	breaker.logger.Log(context.Background(), level, "TRANSITION", "method", "CircuitBreaker",
+		"from", previous.String(), "to", state.String(), "consecutive_failures", breaker.failures)
+	breaker.sendState(previous, state)
+}
+
+// sendState reports state as a gauge when the metrics manager can hold one. Otherwise the transition
+// is sent with SendLog, which every MetricsManagerContract takes without counting it as a call or an error.
+func (breaker *CircuitBreaker) sendState(previous CircuitState, state CircuitState) {
+	if breaker.metricsManager == nil {
+		return
+	}
+	if sender, ok := breaker.metricsManager.(CircuitStateSender); ok {
+		sender.SendCircuitState(circuitBreakerMetric, int(state))
+		return
+	}
+	breaker.metricsManager.SendLog(circuitBreakerMetric, fmt.Sprintf("Circuit breaker %s -> %s", previous, state))
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a MetricsManagerContract that keeps what it is sent.
+type recordingMetrics struct {
+	mu       sync.Mutex
+	measured []string
+	errors   []int
+	logs     []string
+}
+
+func (recording *recordingMetrics) SendMeasuredTime(callName string, _ time.Duration) {
+	recording.mu.Lock()
+	defer recording.mu.Unlock()
+	recording.measured = append(recording.measured, callName)
+}
+
+func (recording *recordingMetrics) SendLog(_ string, message string) {
+	recording.mu.Lock()
+	defer recording.mu.Unlock()
+	recording.logs = append(recording.logs, message)
+}
+
+func (recording *recordingMetrics) Send500Error(_ string, statusCode int, _ string) {
+	recording.mu.Lock()
+	defer recording.mu.Unlock()
+	recording.errors = append(recording.errors, statusCode)
+}
+
+func (recording *recordingMetrics) Send400Error(_ string, statusCode int, _ string) {
+	recording.mu.Lock()
+	defer recording.mu.Unlock()
+	recording.errors = append(recording.errors, statusCode)
+}
+
+// gaugeMetrics also implements CircuitStateSender and CacheLookupSender.
+type gaugeMetrics struct {
+	recordingMetrics
+	states  []int
+	lookups map[string][]bool
+}
+
+func (gauge *gaugeMetrics) SendCircuitState(_ string, state int) {
+	gauge.mu.Lock()
+	defer gauge.mu.Unlock()
+	gauge.states = append(gauge.states, state)
+}
+
+func (gauge *gaugeMetrics) SendCacheLookup(cache string, hit bool) {
+	gauge.mu.Lock()
+	defer gauge.mu.Unlock()
+	if gauge.lookups == nil {
+		gauge.lookups = map[string][]bool{}
+	}
+	gauge.lookups[cache] = append(gauge.lookups[cache], hit)
+}
+
+// fakeClock is a settable time source for the now fields.
+type fakeClock struct {
+	at time.Time
+}
+
+func (clock *fakeClock) now() time.Time {
+	return clock.at
+}
+
+func (clock *fakeClock) advance(duration time.Duration) {
+	clock.at = clock.at.Add(duration)
+}
+
+func TestOutcomeOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		err        error
+		want       callOutcome
+	}{
+		{name: "success", statusCode: http.StatusOK, want: outcomeSuccess},
+		{name: "client error", statusCode: http.StatusBadRequest, err: errors.New("bad"), want: outcomeSuccess},
+		{name: "server error", statusCode: http.StatusBadGateway, err: errors.New("bad gateway"), want: outcomeFailure},
+		{name: "transport error", err: errors.New("connection refused"), want: outcomeFailure},
+		{name: "deadline", statusCode: http.StatusGatewayTimeout, err: context.DeadlineExceeded, want: outcomeFailure},
+		{name: "cancelled", statusCode: 499, err: context.Canceled, want: outcomeIgnored},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := outcomeOf(test.statusCode, test.err); got != test.want {
+				t.Errorf("outcomeOf(%d, %v) = %d, want %d", test.statusCode, test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	config := CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute, HalfOpenSuccesses: 2}
+	// step is one attempt: advance the clock, ask allow, and if allowed record outcome.
+	type step struct {
+		advance     time.Duration
+		outcome     callOutcome
+		wantAllowed bool
+		wantState   CircuitState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "failures below the threshold keep it closed",
+			steps: []step{
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeSuccess, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "consecutive failures open it",
+			steps: []step{
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitOpen},
+				{outcome: outcomeSuccess, wantAllowed: false, wantState: CircuitOpen},
+			},
+		},
+		{
+			name: "ignored outcomes do not count",
+			steps: []step{
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeIgnored, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeIgnored, wantAllowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "probes close it after the open timeout",
+			steps: []step{
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitOpen},
+				{advance: 30 * time.Second, wantAllowed: false, wantState: CircuitOpen},
+				{advance: 30 * time.Second, outcome: outcomeSuccess, wantAllowed: true, wantState: CircuitHalfOpen},
+				{outcome: outcomeSuccess, wantAllowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "a failed probe opens it again",
+			steps: []step{
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitClosed},
+				{outcome: outcomeFailure, wantAllowed: true, wantState: CircuitOpen},
+				{advance: time.Minute, outcome: outcomeFailure, wantAllowed: true, wantState: CircuitOpen},
+				{advance: time.Second, wantAllowed: false, wantState: CircuitOpen},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := &fakeClock{at: time.Unix(0, 0)}
+			breaker := NewCircuitBreaker(config, &recordingMetrics{})
+			breaker.now = clock.now
+			for index, step := range test.steps {
+				clock.advance(step.advance)
+				allowed, probe := breaker.allow()
+				if allowed != step.wantAllowed {
+					t.Fatalf("step %d: allowed = %t, want %t", index, allowed, step.wantAllowed)
+				}
+				if allowed {
+					breaker.record(step.outcome, probe)
+				}
+				if state := breaker.State(); state != step.wantState {
+					t.Fatalf("step %d: state = %s, want %s", index, state, step.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenLetsOneProbeThrough(t *testing.T) {
+	clock := &fakeClock{at: time.Unix(0, 0)}
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second}, nil)
+	breaker.now = clock.now
+	breaker.allow()
+	breaker.record(outcomeFailure, false)
+	clock.advance(time.Second)
+	if allowed, probe := breaker.allow(); !allowed || !probe {
+		t.Fatalf("first attempt after the timeout: allowed %t, probe %t; want the probe", allowed, probe)
+	}
+	if allowed, _ := breaker.allow(); allowed {
+		t.Fatal("second attempt while the probe is out was allowed")
+	}
+	// An attempt that started before the circuit opened says nothing about the service any more.
+	breaker.record(outcomeSuccess, false)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("state = %s after a non-probe success, want %s", state, CircuitHalfOpen)
+	}
+}
+
+func TestCircuitBreakerReportsState(t *testing.T) {
+	clock := &fakeClock{at: time.Unix(0, 0)}
+	config := CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second}
+	run := func(breaker *CircuitBreaker) {
+		breaker.now = clock.now
+		breaker.allow()
+		breaker.record(outcomeFailure, false)
+		clock.advance(time.Second)
+		_, probe := breaker.allow()
+		breaker.record(outcomeSuccess, probe)
+	}
+	tests := []struct {
+		name       string
+		sendGauge  bool
+		wantLogs   []string
+		wantStates []int
+	}{
+		{
+			name: "gauge",
+			// The initial closed state is sent on construction.
+			sendGauge:  true,
+			wantStates: []int{int(CircuitClosed), int(CircuitOpen), int(CircuitHalfOpen), int(CircuitClosed)},
+		},
+		{
+			name: "log",
+			wantLogs: []string{
+				"Circuit breaker closed -> open",
+				"Circuit breaker open -> half-open",
+				"Circuit breaker half-open -> closed",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.sendGauge {
+				gauge := &gaugeMetrics{}
+				run(NewCircuitBreaker(config, gauge))
+				if !reflect.DeepEqual(gauge.states, test.wantStates) {
+					t.Errorf("states = %v, want %v", gauge.states, test.wantStates)
+				}
+				if len(gauge.logs) != 0 {
+					t.Errorf("logs = %v, want nothing alongside the gauge", gauge.logs)
+				}
+				return
+			}
+			recording := &recordingMetrics{}
+			run(NewCircuitBreaker(config, recording))
+			if !reflect.DeepEqual(recording.logs, test.wantLogs) {
+				t.Errorf("logs = %v, want %v", recording.logs, test.wantLogs)
+			}
+			// Transitions are not calls, so they stay out of the latency and error metrics.
+			if len(recording.measured) != 0 || len(recording.errors) != 0 {
+				t.Errorf("measured %v and errors %v for transitions, want none", recording.measured, recording.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/awsgorocket/utils"
+	"net/http"
+)
+
+// Sentinel errors matched by errors.Is against the *RequestError returned by the WithError methods.
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrUnavailable    = errors.New("service unavailable")
+	// ErrEmptyResponse is wrapped by the transport error returned when the service answers 200 without a body.
+	ErrEmptyResponse = errors.New("service answered 200 without data")
+)
+
+// ErrorKind groups failures by what the caller can do about them.
+type ErrorKind int
+
+const (
+	// ErrorKindValidation means the request was rejected as invalid; retrying it will not help.
+	ErrorKindValidation ErrorKind = iota + 1
+	// ErrorKindAuth means the api key or user is not allowed to make the call.
+	ErrorKindAuth
+	// ErrorKindThrottling means the call was rate limited, locally or by the service.
+	ErrorKindThrottling
+	// ErrorKindServer means the service answered with a 5xx.
+	ErrorKindServer
+	// ErrorKindTransport means no usable answer came back: connection errors, timeouts, cancellation
+	// or an unreadable body.
+	ErrorKindTransport
+)
+
+func (kind ErrorKind) String() string {
+	switch kind {
+	case ErrorKindValidation:
+		return "validation"
+	case ErrorKindAuth:
+		return "auth"
+	case ErrorKindThrottling:
+		return "throttling"
+	case ErrorKindServer:
+		return "server"
+	case ErrorKindTransport:
+		return "transport"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(kind))
+	}
+}
+
+// RequestError is returned by the error-returning service methods.
+type RequestError struct {
+	Kind       ErrorKind
+	StatusCode int
+	Message    string
+	// Err is the underlying error, e.g. utils.GenericError or context.DeadlineExceeded.
+	Err error
+}
+
+func (requestError *RequestError) Error() string {
+	return fmt.Sprintf("%s error (status %d): %s", requestError.Kind, requestError.StatusCode, requestError.Message)
+}
+
+func (requestError *RequestError) Unwrap() error {
+	return requestError.Err
+}
+
+// Is matches the sentinel errors, e.g. errors.Is(err, ErrNotFound) for a 404.
+func (requestError *RequestError) Is(target error) bool {
+	sentinel := requestError.sentinel()
+	return sentinel != nil && target == sentinel
+}
+
+func (requestError *RequestError) sentinel() error {
+	switch requestError.Kind {
+	case ErrorKindThrottling:
+		return ErrRateLimited
+	case ErrorKindTransport:
+		// The caller gave up; the service may be perfectly healthy.
+		if errors.Is(requestError.Err, context.Canceled) {
+			return nil
+		}
+		return ErrUnavailable
+	}
+	switch requestError.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound, http.StatusGone:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// kindForStatus classifies a status code the service answered with.
+func kindForStatus(statusCode int) ErrorKind {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrorKindAuth
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorKindThrottling
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorKindServer
+	default:
+		return ErrorKindValidation
+	}
+}
+
+// ResponseError converts a failed response into a *RequestError and returns nil for a 200.
+// Responses whose error did not come from the service, such as connection failures or context
+// cancellation, are transport errors whatever their status code, and so is a 200 without data.
+func ResponseError[T any](serviceResponse response.Response[T]) error {
+	if serviceResponse.StatusCode == http.StatusOK {
+		if serviceResponse.Data != nil {
+			return nil
+		}
+		return &RequestError{
+			Kind:       ErrorKindTransport,
+			StatusCode: http.StatusOK,
+			Message:    ErrEmptyResponse.Error(),
+			Err:        ErrEmptyResponse,
+		}
+	}
+	var err error
+	if serviceResponse.Error != nil {
+		err = *serviceResponse.Error
+	}
+	kind := kindForStatus(serviceResponse.StatusCode)
+	var genericError utils.GenericError
+	if err != nil && !errors.As(err, &genericError) && serviceResponse.StatusCode != http.StatusBadRequest {
+		kind = ErrorKindTransport
+	}
+	return &RequestError{
+		Kind:       kind,
+		StatusCode: serviceResponse.StatusCode,
+		Message:    serviceResponse.Message,
+		Err:        err,
+	}
+}
+
+// invalidRequestError is returned without contacting the service for a request carrying a value the
+// service would reject.
+func invalidRequestError(err error) *RequestError {
+	return &RequestError{Kind: ErrorKindValidation, StatusCode: http.StatusBadRequest, Message: err.Error(), Err: err}
+}
+
+// result splits a response into its data and the error ResponseError reports for it.
+func result[T any](serviceResponse response.Response[T]) (*T, error) {
+	if err := ResponseError(serviceResponse); err != nil {
+		return nil, err
+	}
+	return serviceResponse.Data, nil
+}
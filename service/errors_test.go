@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/awsgorocket/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var sentinels = []error{
+	ErrInvalidRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrRateLimited, ErrUnavailable,
+}
+
+// checkSentinel fails unless err matches want, or no sentinel when want is nil, and no other sentinel.
+func checkSentinel(t *testing.T, err error, want error) {
+	t.Helper()
+	for _, sentinel := range sentinels {
+		if matches := errors.Is(err, sentinel); matches != (sentinel == want) {
+			t.Errorf("errors.Is(%v, %v) = %t", err, sentinel, matches)
+		}
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	genericResponse := func(statusCode int) response.Response[bool] {
+		var err error = utils.GenericError{Message: "failed", StatusCode: statusCode}
+		return response.Response[bool]{StatusCode: statusCode, Message: "failed", Error: &err}
+	}
+	failedResponse := func(statusCode int, err error) response.Response[bool] {
+		return response.Response[bool]{StatusCode: statusCode, Message: err.Error(), Error: &err}
+	}
+	tests := []struct {
+		name           string
+		response       response.Response[bool]
+		wantKind       ErrorKind
+		wantStatusCode int
+		wantSentinel   error
+		// wantCause is matched by errors.Is through Unwrap.
+		wantCause error
+	}{
+		{name: "400", response: genericResponse(400), wantKind: ErrorKindValidation, wantStatusCode: 400, wantSentinel: ErrInvalidRequest},
+		{name: "422", response: genericResponse(422), wantKind: ErrorKindValidation, wantStatusCode: 422, wantSentinel: ErrInvalidRequest},
+		{name: "401", response: genericResponse(401), wantKind: ErrorKindAuth, wantStatusCode: 401, wantSentinel: ErrUnauthorized},
+		{name: "403", response: genericResponse(403), wantKind: ErrorKindAuth, wantStatusCode: 403, wantSentinel: ErrForbidden},
+		{name: "404", response: genericResponse(404), wantKind: ErrorKindValidation, wantStatusCode: 404, wantSentinel: ErrNotFound},
+		{name: "410", response: genericResponse(410), wantKind: ErrorKindValidation, wantStatusCode: 410, wantSentinel: ErrNotFound},
+		{name: "409", response: genericResponse(409), wantKind: ErrorKindValidation, wantStatusCode: 409, wantSentinel: ErrConflict},
+		{name: "429", response: genericResponse(429), wantKind: ErrorKindThrottling, wantStatusCode: 429, wantSentinel: ErrRateLimited},
+		{name: "500", response: genericResponse(500), wantKind: ErrorKindServer, wantStatusCode: 500},
+		{name: "502", response: genericResponse(502), wantKind: ErrorKindServer, wantStatusCode: 502, wantSentinel: ErrUnavailable},
+		{name: "503", response: genericResponse(503), wantKind: ErrorKindServer, wantStatusCode: 503, wantSentinel: ErrUnavailable},
+		{name: "504", response: genericResponse(504), wantKind: ErrorKindServer, wantStatusCode: 504, wantSentinel: ErrUnavailable},
+		{name: "418", response: genericResponse(418), wantKind: ErrorKindValidation, wantStatusCode: 418},
+		{
+			name:           "cancelled",
+			response:       failedResponse(499, fmt.Errorf("sending: %w", context.Canceled)),
+			wantKind:       ErrorKindTransport,
+			wantStatusCode: 499,
+			wantCause:      context.Canceled,
+		},
+		{
+			name:           "deadline",
+			response:       failedResponse(504, fmt.Errorf("sending: %w", context.DeadlineExceeded)),
+			wantKind:       ErrorKindTransport,
+			wantStatusCode: 504,
+			wantSentinel:   ErrUnavailable,
+			wantCause:      context.DeadlineExceeded,
+		},
+		{
+			name:           "connection refused",
+			response:       failedResponse(500, errors.New("dial tcp: connection refused")),
+			wantKind:       ErrorKindTransport,
+			wantStatusCode: 500,
+			wantSentinel:   ErrUnavailable,
+		},
+		{
+			name:           "200 without data",
+			response:       response.Response[bool]{StatusCode: 200},
+			wantKind:       ErrorKindTransport,
+			wantStatusCode: 200,
+			wantSentinel:   ErrUnavailable,
+			wantCause:      ErrEmptyResponse,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ResponseError(test.response)
+			var requestError *RequestError
+			if !errors.As(err, &requestError) {
+				t.Fatalf("ResponseError = %v, want a *RequestError", err)
+			}
+			if requestError.Kind != test.wantKind || requestError.StatusCode != test.wantStatusCode {
+				t.Errorf("ResponseError = %s %d, want %s %d", requestError.Kind, requestError.StatusCode, test.wantKind, test.wantStatusCode)
+			}
+			checkSentinel(t, err, test.wantSentinel)
+			if test.wantCause != nil && !errors.Is(err, test.wantCause) {
+				t.Errorf("errors.Is(%v, %v) = false", err, test.wantCause)
+			}
+		})
+	}
+	data := true
+	if err := ResponseError(response.Response[bool]{StatusCode: 200, Data: &data}); err != nil {
+		t.Errorf("ResponseError(200 with data) = %v, want nil", err)
+	}
+}
+
+func TestFetchWithErrorTransportFailures(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-release:
+		}
+	}))
+	defer hanging.Close()
+	defer close(release)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name           string
+		endpoint       string
+		ctx            func() (context.Context, context.CancelFunc)
+		wantStatusCode int
+		wantMessage    string
+		wantSentinel   error
+		wantCause      error
+	}{
+		{
+			name:           "connection refused",
+			endpoint:       closed.URL,
+			ctx:            func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			wantStatusCode: 500,
+			wantMessage:    "Internal service error",
+			wantSentinel:   ErrUnavailable,
+		},
+		{
+			name:           "cancelled",
+			endpoint:       hanging.URL,
+			ctx:            func() (context.Context, context.CancelFunc) { return cancelled, func() {} },
+			wantStatusCode: 499,
+			wantCause:      context.Canceled,
+		},
+		{
+			name:     "deadline",
+			endpoint: hanging.URL,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			wantStatusCode: 504,
+			wantSentinel:   ErrUnavailable,
+			wantCause:      context.DeadlineExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ticketService := ProvideTicketService(test.endpoint, "api-key", "client", "team", "autocut", &recordingMetrics{},
+				WithRetryPolicy(RetryPolicy{}))
+			ctx, cancel := test.ctx()
+			defer cancel()
+			_, err := ticketService.FetchWithError(ctx, "pk", "rk")
+			var requestError *RequestError
+			if !errors.As(err, &requestError) {
+				t.Fatalf("Fetch = %v, want a *RequestError", err)
+			}
+			if requestError.Kind != ErrorKindTransport || requestError.StatusCode != test.wantStatusCode {
+				t.Errorf("Fetch = %s %d, want transport %d", requestError.Kind, requestError.StatusCode, test.wantStatusCode)
+			}
+			if test.wantMessage != "" && requestError.Message != test.wantMessage {
+				t.Errorf("message %q, want %q", requestError.Message, test.wantMessage)
+			}
+			checkSentinel(t, err, test.wantSentinel)
+			if test.wantCause != nil && !errors.Is(err, test.wantCause) {
+				t.Errorf("errors.Is(%v, %v) = false", err, test.wantCause)
+			}
+		})
+	}
+}
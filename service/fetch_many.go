@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"strings"
+	"sync"
+)
+
+// DefaultFetchConcurrency is how many tickets FetchMany fetches at once unless WithFetchConcurrency
+// says otherwise.
+const DefaultFetchConcurrency = 8
+
+// TicketKey identifies a ticket by its partition and range key.
+type TicketKey struct {
+	PartitionKey string
+	RangeKey     string
+}
+
+// WatchedTicketKey returns the key of the ticket watch is for. Watch range keys are
+// "{TicketPK}_{TicketRK}"; ticket partition keys contain underscores themselves, so the ticket range
+// key is everything after the last one.
+func WatchedTicketKey(watch model.TicketWatchModel) (TicketKey, bool) {
+	index := strings.LastIndex(watch.RangeKey, "_")
+	if index <= 0 || index == len(watch.RangeKey)-1 {
+		return TicketKey{}, false
+	}
+	return TicketKey{PartitionKey: watch.RangeKey[:index], RangeKey: watch.RangeKey[index+1:]}, true
+}
+
+// FetchManyResult holds the outcome of every key passed to FetchMany: each key is in exactly one of
+// Tickets or Errors. Errors are the *RequestError the single Fetch would have returned.
+type FetchManyResult struct {
+	Tickets map[TicketKey]*model.TicketModel
+	Errors  map[TicketKey]error
+}
+
+// FetchTickets runs fetch for every distinct key on at most concurrency goroutines and collects the
+// outcomes. Once ctx is done the keys not yet handed to a worker fail with the context error. It is
+// the worker pool behind TicketService.FetchMany, exported so other TicketServiceContract
+// implementations can share it.
+func FetchTickets(
+	ctx context.Context,
+	keys []TicketKey,
+	concurrency int,
+	fetch func(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error),
+) FetchManyResult {
+	unique := make([]TicketKey, 0, len(keys))
+	seen := make(map[TicketKey]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	fetched := FetchManyResult{
+		Tickets: make(map[TicketKey]*model.TicketModel, len(unique)),
+		Errors:  map[TicketKey]error{},
+	}
+	var mu sync.Mutex
+	record := func(key TicketKey, ticket *model.TicketModel, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			fetched.Errors[key] = err
+			return
+		}
+		fetched.Tickets[key] = ticket
+	}
+
+	jobs := make(chan TicketKey)
+	var workers sync.WaitGroup
+	for worker := 0; worker < min(max(concurrency, 1), len(unique)); worker++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for key := range jobs {
+				ticket, err := fetch(ctx, key.PartitionKey, key.RangeKey)
+				record(key, ticket, err)
+			}
+		}()
+	}
+dispatch:
+	for index, key := range unique {
+		select {
+		case jobs <- key:
+		case <-ctx.Done():
+			for _, skipped := range unique[index:] {
+				record(skipped, nil, contextError(ctx.Err()))
+			}
+			break dispatch
+		}
+	}
+	close(jobs)
+	workers.Wait()
+	return fetched
+}
+
+// contextError shapes a context error the way invoke reports one.
+func contextError(err error) error {
+	return ResponseError(response.Response[model.TicketModel]{
+		StatusCode: contextStatusCode(err),
+		Message:    err.Error(),
+		Error:      &err,
+	})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchTickets(t *testing.T) {
+	missing := errors.New("not found")
+	keys := func(rangeKeys ...string) []TicketKey {
+		all := make([]TicketKey, 0, len(rangeKeys))
+		for _, rangeKey := range rangeKeys {
+			all = append(all, TicketKey{PartitionKey: "pk", RangeKey: rangeKey})
+		}
+		return all
+	}
+	tests := []struct {
+		name        string
+		keys        []TicketKey
+		concurrency int
+		wantTickets int
+		wantErrors  int
+		wantFetches int64
+	}{
+		{name: "no keys", concurrency: 4},
+		{name: "every key", keys: keys("a", "b", "c", "d", "e"), concurrency: 2, wantTickets: 5, wantFetches: 5},
+		{name: "duplicates fetched once", keys: keys("a", "b", "a", "b"), concurrency: 4, wantTickets: 2, wantFetches: 2},
+		{name: "errors per key", keys: keys("a", "missing", "c"), concurrency: 4, wantTickets: 2, wantErrors: 1, wantFetches: 3},
+		{name: "concurrency of at least one", keys: keys("a", "b"), concurrency: 0, wantTickets: 2, wantFetches: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var fetches, inFlight, peak int64
+			fetched := FetchTickets(context.Background(), test.keys, test.concurrency,
+				func(_ context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error) {
+					atomic.AddInt64(&fetches, 1)
+					current := atomic.AddInt64(&inFlight, 1)
+					defer atomic.AddInt64(&inFlight, -1)
+					for {
+						previous := atomic.LoadInt64(&peak)
+						if current <= previous || atomic.CompareAndSwapInt64(&peak, previous, current) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					if rangeKey == "missing" {
+						return nil, missing
+					}
+					return &model.TicketModel{PartitionKey: partitionKey, RangeKey: rangeKey}, nil
+				})
+			if len(fetched.Tickets) != test.wantTickets || len(fetched.Errors) != test.wantErrors {
+				t.Fatalf("%d tickets and %d errors, want %d and %d",
+					len(fetched.Tickets), len(fetched.Errors), test.wantTickets, test.wantErrors)
+			}
+			if fetches != test.wantFetches {
+				t.Errorf("%d fetches, want %d", fetches, test.wantFetches)
+			}
+			if limit := int64(max(test.concurrency, 1)); peak > limit {
+				t.Errorf("%d fetches in flight, want at most %d", peak, limit)
+			}
+			for key, ticket := range fetched.Tickets {
+				if ticket.RangeKey != key.RangeKey {
+					t.Errorf("%+v holds ticket %s", key, ticket.RangeKey)
+				}
+			}
+			if err, ok := fetched.Errors[TicketKey{PartitionKey: "pk", RangeKey: "missing"}]; ok && !errors.Is(err, missing) {
+				t.Errorf("error for the missing key = %v, want %v", err, missing)
+			}
+		})
+	}
+}
+
+func TestFetchTicketsStopsHandingOutKeysOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := []TicketKey{{RangeKey: "a"}, {RangeKey: "b"}, {RangeKey: "c"}, {RangeKey: "d"}}
+	fetched := FetchTickets(ctx, keys, 1, func(_ context.Context, _ string, rangeKey string) (*model.TicketModel, error) {
+		// The only worker cancels and stays busy, so the dispatcher sees ctx done before it can hand out
+		// the next key.
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		return &model.TicketModel{RangeKey: rangeKey}, nil
+	})
+	if len(fetched.Tickets) != 1 || len(fetched.Errors) != len(keys)-1 {
+		t.Fatalf("%d tickets and %d errors, want 1 and %d", len(fetched.Tickets), len(fetched.Errors), len(keys)-1)
+	}
+	for key, err := range fetched.Errors {
+		var requestError *RequestError
+		if !errors.As(err, &requestError) || requestError.StatusCode != 499 || !errors.Is(err, context.Canceled) {
+			t.Errorf("error for %+v = %v, want a 499 matching context.Canceled", key, err)
+		}
+	}
+}
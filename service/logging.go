@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler drops every record. It is the default so the library stays silent unless it is
+// given a logger with WithLogger.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool {
+	return false
+}
+
+func (discardHandler) Handle(context.Context, slog.Record) error {
+	return nil
+}
+
+func (handler discardHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return handler
+}
+
+func (handler discardHandler) WithGroup(string) slog.Handler {
+	return handler
+}
+
+func loggerOrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(discardHandler{})
+	}
+	return logger
+}
+
+// fieldAttrs turns a serviceCall's key/value fields into slog arguments, dereferencing the optional
+// string fields of the request models.
+func fieldAttrs(fields []any) []any {
+	attrs := make([]any, 0, len(fields))
+	for i := 0; i+1 < len(fields); i += 2 {
+		value := fields[i+1]
+		if pointer, ok := value.(*string); ok {
+			if pointer == nil {
+				continue
+			}
+			value = *pointer
+		}
+		attrs = append(attrs, fields[i], value)
+	}
+	return attrs
+}
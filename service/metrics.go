@@ -0,0 +1,15 @@
+package service
+
+// CircuitStateSender is implemented by metrics managers that can hold the circuit breaker's current
+// state, such as prommetrics.MetricsManager. state is a CircuitState: 0 closed, 1 open, 2 half-open.
+// Managers that do not implement it get a SendMeasuredTime data point named for the new state instead.
+type CircuitStateSender interface {
+	SendCircuitState(callName string, state int)
+}
+
+// CacheLookupSender is implemented by metrics managers that count cache lookups, such as
+// prommetrics.MetricsManager. cache names the cache, e.g. "ticket". Managers that do not implement
+// it are told nothing; CacheStats has the same counts.
+type CacheLookupSender interface {
+	SendCacheLookup(cache string, hit bool)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures the services built by the Provide* functions. Options that only make sense
+// for one service are ignored by the others.
+type Option func(*serviceOptions)
+
+type serviceOptions struct {
+	deduplicationWindow time.Duration
+	commentService      TicketCommentServiceContract
+	autocutRateLimit    *AutocutRateLimit
+	autocutSpool        *AutocutSpool
+	retryPolicy         RetryPolicy
+	circuitBreaker      *CircuitBreaker
+	httpClient          *http.Client
+	logger              *slog.Logger
+	piiAllowList        []PIIKind
+	tracerProvider      trace.TracerProvider
+	propagator          propagation.TextMapPropagator
+	timeout             time.Duration
+	userAgent           string
+	headers             http.Header
+	fetchConcurrency    int
+	ticketTransitions   TicketTransitions
+	watchService        TicketWatchServiceContract
+}
+
+func applyOptions(opts []Option) serviceOptions {
+	options := serviceOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithAutocutDeduplication makes TicketService.CreateAutocut open at most one ticket per fingerprint
+// within window. Repeats are appended to the open ticket as comments through commentService.
+func WithAutocutDeduplication(window time.Duration, commentService TicketCommentServiceContract) Option {
+	return func(options *serviceOptions) {
+		options.deduplicationWindow = window
+		options.commentService = commentService
+	}
+}
+
+// WithAutocutRateLimit throttles TicketService.CreateAutocut with token buckets. Dropped autocuts are
+// reported through the metrics manager as 429s.
+func WithAutocutRateLimit(limit AutocutRateLimit) Option {
+	return func(options *serviceOptions) {
+		options.autocutRateLimit = &limit
+	}
+}
+
+// WithAutocutSpool persists autocuts that fail with a retryable status to spool. Run
+// TicketService.RunAutocutSpool to replay them.
+func WithAutocutSpool(spool *AutocutSpool) Option {
+	return func(options *serviceOptions) {
+		options.autocutSpool = spool
+	}
+}
+
+// WithRetryPolicy retries failed calls according to policy. Reads, idempotent writes and creates,
+// which carry an idempotency key, are retried on any retryable failure; other writes only when the
+// service cannot have processed them.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(options *serviceOptions) {
+		options.retryPolicy = policy
+	}
+}
+
+// WithCircuitBreaker routes every call through breaker. Pass the same breaker to all services that
+// share an endpoint so they trip together.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(options *serviceOptions) {
+		options.circuitBreaker = breaker
+	}
+}
+
+// WithHTTPClient sends requests through httpClient, e.g. one with a proxy, custom TLS roots, client
+// certificates or a tuned connection pool. Share one client between services to share its pool.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(options *serviceOptions) {
+		options.httpClient = httpClient
+	}
+}
+
+// WithTimeout bounds each attempt, including reading the response body. The HTTP client passed to
+// WithHTTPClient is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *serviceOptions) {
+		options.timeout = timeout
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(options *serviceOptions) {
+		options.userAgent = userAgent
+	}
+}
+
+// WithHeaders adds headers to every request. They cannot replace the Content-Type, x-api-key or
+// Idempotency-Key headers the protocol relies on.
+func WithHeaders(headers http.Header) Option {
+	return func(options *serviceOptions) {
+		if options.headers == nil {
+			options.headers = http.Header{}
+		}
+		for name, values := range headers {
+			for _, value := range values {
+				options.headers.Add(name, value)
+			}
+		}
+	}
+}
+
+// WithLogger writes structured records for every call to logger: Debug as calls start, Info when they
+// complete or are retried, Warn and Error when they fail. Without it the services log nothing.
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *serviceOptions) {
+		options.logger = logger
+	}
+}
+
+// WithPIIAllowList stops redacting the given kinds of personal data from log records and error
+// messages. Meant for debugging environments; by default emails, user ids and free text are masked.
+func WithPIIAllowList(kinds ...PIIKind) Option {
+	return func(options *serviceOptions) {
+		options.piiAllowList = append(options.piiAllowList, kinds...)
+	}
+}
+
+// WithTracerProvider creates the span for every call from provider instead of the global one set with
+// otel.SetTracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(options *serviceOptions) {
+		options.tracerProvider = provider
+	}
+}
+
+// WithPropagator replaces the W3C trace context headers sent with every request, e.g. to add baggage.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(options *serviceOptions) {
+		options.propagator = propagator
+	}
+}
+
+// WithFetchConcurrency bounds how many tickets TicketService.FetchMany fetches, and how many watch
+// entries TicketService.Transition updates, at once. The default is DefaultFetchConcurrency.
+func WithFetchConcurrency(concurrency int) Option {
+	return func(options *serviceOptions) {
+		options.fetchConcurrency = concurrency
+	}
+}
+
+// WithTicketTransitions replaces the state machine TicketService.Transition enforces, which is
+// DefaultTicketTransitions otherwise.
+func WithTicketTransitions(transitions TicketTransitions) Option {
+	return func(options *serviceOptions) {
+		options.ticketTransitions = transitions
+	}
+}
+
+// WithTicketWatchService lets TicketService.Transition refresh the status on the watch entries of the
+// tickets it moves.
+func WithTicketWatchService(watchService TicketWatchServiceContract) Option {
+	return func(options *serviceOptions) {
+		options.watchService = watchService
+	}
+}
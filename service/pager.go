@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_member_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+)
+
+// ErrPageLoop is returned when the service hands back the paging keys it was just given, which would
+// otherwise page forever.
+var ErrPageLoop = errors.New("list call returned the same paging keys twice")
+
+// PageKeys are the keys a list call resumes after. Empty keys ask for the first page.
+type PageKeys struct {
+	PartitionKey *string
+	RangeKey     *string
+}
+
+func (keys PageKeys) empty() bool {
+	return keys.RangeKey == nil || len(*keys.RangeKey) == 0
+}
+
+func (keys PageKeys) equal(other PageKeys) bool {
+	return stringValue(keys.PartitionKey) == stringValue(other.PartitionKey) &&
+		stringValue(keys.RangeKey) == stringValue(other.RangeKey)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// PageFunc fetches the page after keys and returns its results with the keys of the page after it.
+type PageFunc[T any] func(ctx context.Context, keys PageKeys) ([]*T, PageKeys, error)
+
+// Pager drives a list call page by page:
+//
+//	pager := service.NewUserWatchListPager(watchService, request)
+//	for !pager.Done() {
+//		watches, err := pager.Next(ctx)
+//		...
+//	}
+//
+// A failed page stops the pager; Next and All keep returning that error. A Pager is not safe for
+// concurrent use.
+type Pager[T any] struct {
+	fetch PageFunc[T]
+	keys  PageKeys
+	done  bool
+	err   error
+	// held keeps the results All fetched beyond its cap for the next call.
+	held []*T
+}
+
+// NewPager pages through fetch starting after start, which is usually empty.
+func NewPager[T any](fetch PageFunc[T], start PageKeys) *Pager[T] {
+	return &Pager[T]{fetch: fetch, keys: start}
+}
+
+// Done reports whether the last page has been returned or the pager stopped on an error.
+func (pager *Pager[T]) Done() bool {
+	return pager.done && len(pager.held) == 0
+}
+
+// Err returns the error that stopped the pager, if any.
+func (pager *Pager[T]) Err() error {
+	return pager.err
+}
+
+// Next fetches the next page. It returns nil, nil once the pager is done.
+func (pager *Pager[T]) Next(ctx context.Context) ([]*T, error) {
+	if len(pager.held) > 0 {
+		held := pager.held
+		pager.held = nil
+		return held, nil
+	}
+	if pager.done {
+		return nil, pager.err
+	}
+	results, next, err := pager.fetch(ctx, pager.keys)
+	if err != nil {
+		pager.stop(err)
+		return nil, err
+	}
+	switch {
+	case next.empty():
+		pager.done = true
+	case next.equal(pager.keys):
+		pager.stop(ErrPageLoop)
+		return results, ErrPageLoop
+	}
+	pager.keys = next
+	return results, nil
+}
+
+// All collects the remaining pages. When maxItems is positive it stops once that many results are
+// collected; Done tells whether more remain, and the next Next or All picks up right after the last
+// result returned. On error the results collected so far are returned with it.
+func (pager *Pager[T]) All(ctx context.Context, maxItems int) ([]*T, error) {
+	var all []*T
+	for !pager.Done() {
+		results, err := pager.Next(ctx)
+		all = append(all, results...)
+		if err != nil {
+			return all, err
+		}
+		if maxItems > 0 && len(all) >= maxItems {
+			pager.held = append([]*T(nil), all[maxItems:]...)
+			return all[:maxItems:maxItems], nil
+		}
+	}
+	return all, pager.err
+}
+
+func (pager *Pager[T]) stop(err error) {
+	pager.done = true
+	pager.err = err
+}
+
+func NewTicketPager(ticketService TicketServiceContract, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) *Pager[model2.TicketModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketModel, PageKeys, error) {
+		fetchAllRequest.LastRangeKey = keys.RangeKey
+		page, err := ticketService.FetchAllWithError(ctx, fetchAllRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{RangeKey: fetchAllRequest.LastRangeKey})
+}
+
+func NewTicketsByUserPager(ticketService TicketServiceContract, fetchRequest ticket_model_request.TicketModelByUserRequest) *Pager[model2.TicketModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketModel, PageKeys, error) {
+		fetchRequest.LastPartitionKey = keys.PartitionKey
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := ticketService.FetchByUserWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{PartitionKey: fetchRequest.LastPartitionKey, RangeKey: fetchRequest.LastRangeKey})
+}
+
+func NewCommentPager(commentService TicketCommentServiceContract, fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) *Pager[model2.TicketCommentModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketCommentModel, PageKeys, error) {
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := commentService.FetchAllWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{RangeKey: fetchRequest.LastRangeKey})
+}
+
+func NewCommentsByUserPager(commentService TicketCommentServiceContract, fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) *Pager[model2.TicketCommentModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketCommentModel, PageKeys, error) {
+		fetchRequest.LastPartitionKey = keys.PartitionKey
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := commentService.FetchByUserWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{PartitionKey: fetchRequest.LastPartitionKey, RangeKey: fetchRequest.LastRangeKey})
+}
+
+func NewTeamPager(teamService TicketTeamServiceContract, clientId string) *Pager[model2.TicketTeamModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketTeamModel, PageKeys, error) {
+		page, err := teamService.FetchAllWithError(ctx, clientId, keys.RangeKey)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{})
+}
+
+func NewTeamMemberPager(memberService TicketTeamMemberServiceContract, fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) *Pager[model2.TicketTeamMemberModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketTeamMemberModel, PageKeys, error) {
+		fetchAllRequest.LastRangeKey = keys.RangeKey
+		page, err := memberService.FetchAllWithError(ctx, fetchAllRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{RangeKey: fetchAllRequest.LastRangeKey})
+}
+
+func NewTeamMembersByUserPager(memberService TicketTeamMemberServiceContract, fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) *Pager[model2.TicketTeamMemberModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketTeamMemberModel, PageKeys, error) {
+		fetchRequest.LastPartitionKey = keys.PartitionKey
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := memberService.FetchByUserWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{PartitionKey: fetchRequest.LastPartitionKey, RangeKey: fetchRequest.LastRangeKey})
+}
+
+func NewUserWatchListPager(watchService TicketWatchServiceContract, fetchRequest ticket_watch_request.TicketWatchUserListRequest) *Pager[model2.TicketWatchModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketWatchModel, PageKeys, error) {
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := watchService.GetUserWatchListWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{RangeKey: fetchRequest.LastRangeKey})
+}
+
+func NewTicketWatchersPager(watchService TicketWatchServiceContract, fetchRequest ticket_watch_request.TicketWatchersListRequest) *Pager[model2.TicketWatchModel] {
+	return NewPager(func(ctx context.Context, keys PageKeys) ([]*model2.TicketWatchModel, PageKeys, error) {
+		fetchRequest.LastPartitionKey = keys.PartitionKey
+		fetchRequest.LastRangeKey = keys.RangeKey
+		page, err := watchService.GetTicketWatchersWithError(ctx, fetchRequest)
+		if err != nil {
+			return nil, PageKeys{}, err
+		}
+		return page.Results, PageKeys{PartitionKey: page.LastPartitionKey, RangeKey: page.LastRangeKey}, nil
+	}, PageKeys{PartitionKey: fetchRequest.LastPartitionKey, RangeKey: fetchRequest.LastRangeKey})
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// scriptedPage is one answer of a scripted list call.
+type scriptedPage struct {
+	results []int
+	next    string
+	err     error
+}
+
+// scriptedPages answers each fetch with the next page and records the range keys it was asked for.
+func scriptedPages(pages []scriptedPage, asked *[]string) PageFunc[int] {
+	return func(_ context.Context, keys PageKeys) ([]*int, PageKeys, error) {
+		*asked = append(*asked, stringValue(keys.RangeKey))
+		page := pages[len(*asked)-1]
+		if page.err != nil {
+			return nil, PageKeys{}, page.err
+		}
+		results := make([]*int, 0, len(page.results))
+		for index := range page.results {
+			results = append(results, &page.results[index])
+		}
+		next := PageKeys{}
+		if page.next != "" {
+			next.RangeKey = &page.next
+		}
+		return results, next, nil
+	}
+}
+
+func values(results []*int) []int {
+	all := make([]int, 0, len(results))
+	for _, result := range results {
+		all = append(all, *result)
+	}
+	return all
+}
+
+func TestPagerAll(t *testing.T) {
+	failure := errors.New("unavailable")
+	tests := []struct {
+		name      string
+		pages     []scriptedPage
+		maxItems  int
+		want      []int
+		wantErr   error
+		wantAsked []string
+		wantDone  bool
+	}{
+		{
+			name:      "single page",
+			pages:     []scriptedPage{{results: []int{1, 2}}},
+			want:      []int{1, 2},
+			wantAsked: []string{""},
+			wantDone:  true,
+		},
+		{
+			name: "follows the keys",
+			pages: []scriptedPage{
+				{results: []int{1, 2}, next: "b"},
+				{results: []int{3}, next: "c"},
+				{results: []int{4}},
+			},
+			want:      []int{1, 2, 3, 4},
+			wantAsked: []string{"", "b", "c"},
+			wantDone:  true,
+		},
+		{
+			name: "stops at the same keys twice",
+			pages: []scriptedPage{
+				{results: []int{1}, next: "b"},
+				{results: []int{2}, next: "b"},
+				{results: []int{3}},
+			},
+			want:      []int{1, 2},
+			wantErr:   ErrPageLoop,
+			wantAsked: []string{"", "b"},
+			wantDone:  true,
+		},
+		{
+			name: "returns what it has with an error",
+			pages: []scriptedPage{
+				{results: []int{1}, next: "b"},
+				{err: failure},
+			},
+			want:      []int{1},
+			wantErr:   failure,
+			wantAsked: []string{"", "b"},
+			wantDone:  true,
+		},
+		{
+			name: "caps at maxItems",
+			pages: []scriptedPage{
+				{results: []int{1, 2}, next: "b"},
+				{results: []int{3, 4}, next: "c"},
+				{results: []int{5}},
+			},
+			maxItems:  3,
+			want:      []int{1, 2, 3},
+			wantAsked: []string{"", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var asked []string
+			pager := NewPager(scriptedPages(test.pages, &asked), PageKeys{})
+			results, err := pager.All(context.Background(), test.maxItems)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("All error = %v, want %v", err, test.wantErr)
+			}
+			if got := values(results); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("All = %v, want %v", got, test.want)
+			}
+			if !reflect.DeepEqual(asked, test.wantAsked) {
+				t.Errorf("asked for %q, want %q", asked, test.wantAsked)
+			}
+			if pager.Done() != test.wantDone {
+				t.Errorf("Done = %t, want %t", pager.Done(), test.wantDone)
+			}
+			if !errors.Is(pager.Err(), test.wantErr) {
+				t.Errorf("Err = %v, want %v", pager.Err(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPagerResumesAfterMaxItems(t *testing.T) {
+	var asked []string
+	pager := NewPager(scriptedPages([]scriptedPage{
+		{results: []int{1, 2, 3}, next: "b"},
+		{results: []int{4}},
+	}, &asked), PageKeys{})
+	first, err := pager.All(context.Background(), 2)
+	if err != nil || !reflect.DeepEqual(values(first), []int{1, 2}) {
+		t.Fatalf("first All = %v, %v; want [1 2]", values(first), err)
+	}
+	held, err := pager.Next(context.Background())
+	if err != nil || !reflect.DeepEqual(values(held), []int{3}) {
+		t.Fatalf("Next = %v, %v; want the held [3]", values(held), err)
+	}
+	rest, err := pager.All(context.Background(), 0)
+	if err != nil || !reflect.DeepEqual(values(rest), []int{4}) {
+		t.Fatalf("second All = %v, %v; want [4]", values(rest), err)
+	}
+	if !pager.Done() || !reflect.DeepEqual(asked, []string{"", "b"}) {
+		t.Errorf("Done = %t after asking for %q", pager.Done(), asked)
+	}
+	if results, err := pager.Next(context.Background()); results != nil || err != nil {
+		t.Errorf("Next when done = %v, %v; want nil, nil", results, err)
+	}
+}
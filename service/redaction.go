@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PIIKind is a category of personal data the services keep out of log records and error messages.
+type PIIKind string
+
+const (
+	PIIEmail  PIIKind = "email"
+	PIIUserId PIIKind = "user_id"
+	// PIIFreeText covers text people type: titles, descriptions, comments and reasons.
+	PIIFreeText PIIKind = "free_text"
+)
+
+const redactedValue = "[REDACTED]"
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// userIdParamPattern finds user ids in request URLs quoted by transport errors.
+	userIdParamPattern = regexp.MustCompile(`(userId=)[^&"\s]+`)
+)
+
+// piiKinds classifies the attribute keys the services log with.
+var piiKinds = map[string]PIIKind{
+	"email":       PIIEmail,
+	"user_id":     PIIUserId,
+	"actor":       PIIUserId,
+	"title":       PIIFreeText,
+	"description": PIIFreeText,
+	"files":       PIIFreeText,
+	"comment":     PIIFreeText,
+	"reason":      PIIFreeText,
+}
+
+// redactor masks every PIIKind that is not allowed. The zero value redacts everything.
+type redactor struct {
+	allowed map[PIIKind]bool
+}
+
+func newRedactor(allowed []PIIKind) redactor {
+	redactor := redactor{allowed: map[PIIKind]bool{}}
+	for _, kind := range allowed {
+		redactor.allowed[kind] = true
+	}
+	return redactor
+}
+
+func (redactor redactor) redacts(kind PIIKind) bool {
+	return !redactor.allowed[kind]
+}
+
+// sensitiveValues returns the values of a call's fields that must not appear in its error messages,
+// e.g. the user id a service echoes back in a 404.
+func (redactor redactor) sensitiveValues(fields []any) []string {
+	var values []string
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, _ := fields[i].(string)
+		kind, ok := piiKinds[key]
+		if !ok || !redactor.redacts(kind) {
+			continue
+		}
+		value, ok := fields[i+1].(string)
+		if ok && len(strings.TrimSpace(value)) > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// text masks emails, user ids in URLs and the given sensitive values within free-form text such as
+// an error message.
+func (redactor redactor) text(text string, sensitive ...string) string {
+	for _, value := range sensitive {
+		text = replaceToken(text, value)
+	}
+	if redactor.redacts(PIIEmail) {
+		text = emailPattern.ReplaceAllString(text, redactedValue)
+	}
+	if redactor.redacts(PIIUserId) {
+		text = userIdParamPattern.ReplaceAllString(text, "${1}"+redactedValue)
+	}
+	return text
+}
+
+// replaceToken masks value where it stands on its own, so a short user id such as "al" does not
+// mangle every word containing it.
+func replaceToken(text string, value string) string {
+	var builder strings.Builder
+	for {
+		index := strings.Index(text, value)
+		if index < 0 {
+			builder.WriteString(text)
+			return builder.String()
+		}
+		end := index + len(value)
+		standalone := (index == 0 || !isTokenByte(text[index-1])) && (end == len(text) || !isTokenByte(text[end]))
+		builder.WriteString(text[:index])
+		if standalone {
+			builder.WriteString(redactedValue)
+		} else {
+			builder.WriteString(value)
+		}
+		text = text[end:]
+	}
+}
+
+func isTokenByte(b byte) bool {
+	return b == '_' || b == '-' ||
+		('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// error wraps err so its message is redacted. errors.Is and errors.As still reach err, except that
+// a *url.Error from the transport is replaced by a copy with the URL redacted, since its URL field
+// carries the raw query string.
+func (redactor redactor) error(err error, sensitive ...string) error {
+	if err == nil {
+		return nil
+	}
+	if urlError, ok := err.(*url.Error); ok {
+		redacted := *urlError
+		redacted.URL = redactor.text(urlError.URL, sensitive...)
+		err = &redacted
+	}
+	message := redactor.text(err.Error(), sensitive...)
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (redactedError *redactedError) Error() string {
+	return redactedError.message
+}
+
+func (redactedError *redactedError) Unwrap() error {
+	return redactedError.err
+}
+
+func (redactor redactor) attr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, 0, len(group))
+		for _, member := range group {
+			redacted = append(redacted, redactor.attr(member))
+		}
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindString:
+		if kind, ok := piiKinds[attr.Key]; ok && redactor.redacts(kind) {
+			return slog.String(attr.Key, redactedValue)
+		}
+		return slog.String(attr.Key, redactor.text(value.String()))
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, redactor.text(err.Error()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+// redactingHandler applies a redactor to every attribute before passing records on.
+type redactingHandler struct {
+	next     slog.Handler
+	redactor redactor
+}
+
+func redactLogger(logger *slog.Logger, redactor redactor) *slog.Logger {
+	if _, silent := logger.Handler().(discardHandler); silent {
+		return logger
+	}
+	return slog.New(redactingHandler{next: logger.Handler(), redactor: redactor})
+}
+
+func (handler redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.next.Enabled(ctx, level)
+}
+
+func (handler redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(handler.redactor.attr(attr))
+		return true
+	})
+	return handler.next.Handle(ctx, redacted)
+}
+
+func (handler redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		redacted = append(redacted, handler.redactor.attr(attr))
+	}
+	return redactingHandler{next: handler.next.WithAttrs(redacted), redactor: handler.redactor}
+}
+
+func (handler redactingHandler) WithGroup(name string) slog.Handler {
+	return redactingHandler{next: handler.next.WithGroup(name), redactor: handler.redactor}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactorText(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowed   []PIIKind
+		text      string
+		sensitive []string
+		want      string
+	}{
+		{
+			name: "email",
+			text: "no member jane.doe@example.com",
+			want: "no member [REDACTED]",
+		},
+		{
+			name:    "email allowed",
+			allowed: []PIIKind{PIIEmail},
+			text:    "no member jane.doe@example.com",
+			want:    "no member jane.doe@example.com",
+		},
+		{
+			name: "user id in a URL",
+			text: `Post "https://host/api?action=fetch&userId=u-123&controller=watch": EOF`,
+			want: `Post "https://host/api?action=fetch&userId=[REDACTED]&controller=watch": EOF`,
+		},
+		{
+			name:    "user id allowed",
+			allowed: []PIIKind{PIIUserId},
+			text:    "https://host/api?userId=u-123",
+			want:    "https://host/api?userId=u-123",
+		},
+		{
+			name:      "sensitive value on its own",
+			text:      "user al not found",
+			sensitive: []string{"al"},
+			want:      "user [REDACTED] not found",
+		},
+		{
+			name:      "sensitive value inside a word",
+			text:      "already deleted: al",
+			sensitive: []string{"al"},
+			want:      "already deleted: [REDACTED]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := newRedactor(test.allowed).text(test.text, test.sensitive...); got != test.want {
+				t.Errorf("text = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRedactorSensitiveValues(t *testing.T) {
+	fields := []any{"user_id", "u-1", "actor", "  ", "title", "Disk full", "severity", 2, "team_id", "team"}
+	tests := []struct {
+		name    string
+		allowed []PIIKind
+		want    []string
+	}{
+		{name: "redact everything", want: []string{"u-1", "Disk full"}},
+		{name: "free text allowed", allowed: []PIIKind{PIIFreeText}, want: []string{"u-1"}},
+		{name: "everything allowed", allowed: []PIIKind{PIIEmail, PIIUserId, PIIFreeText}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := newRedactor(test.allowed).sensitiveValues(fields)
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("sensitiveValues = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRedactorError(t *testing.T) {
+	sentinel := errors.New("connection reset")
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantURL     string
+	}{
+		{
+			name:        "clean error passes through",
+			err:         sentinel,
+			wantMessage: "connection reset",
+		},
+		{
+			name:        "message is redacted",
+			err:         errors.Join(errors.New("no member jane@example.com"), sentinel),
+			wantMessage: "no member [REDACTED]\nconnection reset",
+		},
+		{
+			name:        "url.Error keeps its type with the URL redacted",
+			err:         &url.Error{Op: "Post", URL: "https://host/api?userId=u-123", Err: sentinel},
+			wantMessage: `Post "https://host/api?userId=[REDACTED]": connection reset`,
+			wantURL:     "https://host/api?userId=[REDACTED]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := redactor{}.error(test.err)
+			if err.Error() != test.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), test.wantMessage)
+			}
+			if !errors.Is(err, sentinel) {
+				t.Error("errors.Is no longer reaches the wrapped error")
+			}
+			var urlError *url.Error
+			if found := errors.As(err, &urlError); found != (test.wantURL != "") {
+				t.Fatalf("errors.As(*url.Error) = %t, want %t", found, !found)
+			}
+			if urlError != nil && urlError.URL != test.wantURL {
+				t.Errorf("URL = %q, want %q", urlError.URL, test.wantURL)
+			}
+		})
+	}
+	if (redactor{}).error(nil) != nil {
+		t.Error("error(nil) is not nil")
+	}
+}
+
+func TestRedactingHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []PIIKind
+		log     func(logger *slog.Logger)
+		want    string
+	}{
+		{
+			name: "PII keys",
+			log: func(logger *slog.Logger) {
+				logger.Info("CREATED", "title", "Disk full", "user_id", "u-1", "severity", 2)
+			},
+			want: `level=INFO msg=CREATED title=[REDACTED] user_id=[REDACTED] severity=2`,
+		},
+		{
+			name:    "allowed kinds",
+			allowed: []PIIKind{PIIFreeText},
+			log: func(logger *slog.Logger) {
+				logger.Info("CREATED", "title", "Disk full", "user_id", "u-1")
+			},
+			want: `level=INFO msg=CREATED title="Disk full" user_id=[REDACTED]`,
+		},
+		{
+			name: "errors and other strings",
+			log: func(logger *slog.Logger) {
+				logger.Warn("FAILED", "error", errors.New("no member jane@example.com"), "team_id", "ops@example.com")
+			},
+			want: `level=WARN msg=FAILED error="no member [REDACTED]" team_id=[REDACTED]`,
+		},
+		{
+			name: "With and groups",
+			log: func(logger *slog.Logger) {
+				logger.With("actor", "u-1").Info("TRANSITIONED", slog.Group("ticket", "title", "Disk full"))
+			},
+			want: `level=INFO msg=TRANSITIONED actor=[REDACTED] ticket.title=[REDACTED]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.TimeKey && len(groups) == 0 {
+						return slog.Attr{}
+					}
+					return attr
+				},
+			})
+			test.log(redactLogger(slog.New(handler), newRedactor(test.allowed)))
+			if got := strings.TrimSpace(buffer.String()); got != test.want {
+				t.Errorf("logged %s, want %s", got, test.want)
+			}
+		})
+	}
+}
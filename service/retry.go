@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed calls are retried. The zero value makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. A Retry-After longer than it ends the retries.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each attempt; values below 1 are treated as 1.
+	Multiplier float64
+	// Jitter spreads each backoff by up to this fraction either way, e.g. 0.2 for ±20%.
+	Jitter float64
+	// RetryableStatusCodes are the statuses worth another attempt. Transport errors other than
+	// context cancellation are always retryable.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy makes up to three attempts, starting at 200ms and doubling with ±20% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// callKind tells the retry loop how safe it is to repeat a call.
+type callKind int
+
+const (
+	// unsetCall is the zero value. invoke refuses it so every call states how safe it is to repeat.
+	unsetCall callKind = iota
+	// writeCall may have taken effect even if it failed, so it is only retried when the service
+	// cannot have processed it.
+	writeCall
+	// idempotentWriteCall leaves the same state however many times it is applied.
+	idempotentWriteCall
+	// readCall has no side effects.
+	readCall
+)
+
+// repeatable reports whether the call leaves the same state however often it is sent.
+func (kind callKind) repeatable() bool {
+	return kind == idempotentWriteCall || kind == readCall
+}
+
+// backoff returns the wait before attempt+1, where attempt counts from 1.
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := math.Max(policy.Multiplier, 1)
+	wait := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 {
+		wait = math.Min(wait, float64(policy.MaxBackoff))
+	}
+	if policy.Jitter > 0 {
+		wait *= 1 - policy.Jitter + 2*policy.Jitter*rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+// wait returns how long to wait before attempt+1: the backoff, or the server's Retry-After when that
+// is longer. A Retry-After beyond MaxBackoff means the service wants more time than the policy allows
+// a call to spend, so the call is given up instead.
+func (policy RetryPolicy) wait(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if policy.MaxBackoff > 0 && retryAfter > policy.MaxBackoff {
+		return 0, false
+	}
+	return max(retryAfter, policy.backoff(attempt)), true
+}
+
+// shouldRetry reports whether a failed attempt of kind may be repeated. Plain writes are only
+// repeated when the service rejected them before doing any work: throttling, 503 or a refused
+// connection.
+func (policy RetryPolicy) shouldRetry(kind callKind, statusCode int, err error) bool {
+	if statusCode == 0 {
+		if kind.repeatable() {
+			return true
+		}
+		var opError *net.OpError
+		return errors.As(err, &opError) && opError.Op == "dial"
+	}
+	if !slices.Contains(policy.RetryableStatusCodes, statusCode) {
+		return false
+	}
+	if kind.repeatable() {
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
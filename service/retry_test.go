@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "first wait", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2}, attempt: 1, min: 100 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "grows by the multiplier", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2}, attempt: 3, min: 400 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "multiplier below 1", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 0.5}, attempt: 3, min: 100 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "capped", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}, attempt: 4, min: 300 * time.Millisecond, max: 300 * time.Millisecond},
+		{name: "jitter", policy: RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2, Jitter: 0.2}, attempt: 2, min: 160 * time.Millisecond, max: 240 * time.Millisecond},
+		{name: "jitter on the cap", policy: RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second, Jitter: 0.5}, attempt: 5, min: 500 * time.Millisecond, max: 1500 * time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if backoff := test.policy.backoff(test.attempt); backoff < test.min || backoff > test.max {
+					t.Fatalf("backoff(%d) = %v, want within [%v, %v]", test.attempt, backoff, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	dialError := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readError := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+	tests := []struct {
+		name       string
+		statusCode int
+		err        error
+		// want holds the answer for unsetCall, writeCall, idempotentWriteCall and readCall.
+		want [4]bool
+	}{
+		{name: "429", statusCode: http.StatusTooManyRequests, want: [4]bool{true, true, true, true}},
+		{name: "503", statusCode: http.StatusServiceUnavailable, want: [4]bool{true, true, true, true}},
+		{name: "500", statusCode: http.StatusInternalServerError, want: [4]bool{false, false, true, true}},
+		{name: "504", statusCode: http.StatusGatewayTimeout, want: [4]bool{false, false, true, true}},
+		{name: "408", statusCode: http.StatusRequestTimeout, want: [4]bool{false, false, true, true}},
+		{name: "404", statusCode: http.StatusNotFound},
+		{name: "400", statusCode: http.StatusBadRequest},
+		{name: "refused connection", err: dialError, want: [4]bool{true, true, true, true}},
+		{name: "reset connection", err: readError, want: [4]bool{false, false, true, true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for kind, want := range test.want {
+				if got := policy.shouldRetry(callKind(kind), test.statusCode, test.err); got != want {
+					t.Errorf("shouldRetry(kind %d) = %t, want %t", kind, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{header: "", want: 0},
+		{header: "2", want: 2 * time.Second},
+		{header: "0", want: 0},
+		{header: "-5", want: 0},
+		{header: "soon", want: 0},
+		{header: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{header: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+	}
+	for _, test := range tests {
+		if got := parseRetryAfter(test.header, now); got != test.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", test.header, got, test.want)
+		}
+	}
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter time.Duration
+		want       time.Duration
+		wantRetry  bool
+	}{
+		{name: "backoff without Retry-After", policy: policy, attempt: 2, want: 200 * time.Millisecond, wantRetry: true},
+		{name: "shorter Retry-After", policy: policy, attempt: 2, retryAfter: 50 * time.Millisecond, want: 200 * time.Millisecond, wantRetry: true},
+		{name: "longer Retry-After", policy: policy, attempt: 1, retryAfter: 500 * time.Millisecond, want: 500 * time.Millisecond, wantRetry: true},
+		{name: "Retry-After at the cap", policy: policy, attempt: 1, retryAfter: time.Second, want: time.Second, wantRetry: true},
+		{name: "Retry-After beyond the cap", policy: policy, attempt: 1, retryAfter: time.Minute},
+		{
+			name:       "no cap",
+			policy:     RetryPolicy{MaxAttempts: 2, InitialBackoff: 100 * time.Millisecond},
+			attempt:    1,
+			retryAfter: time.Minute,
+			want:       time.Minute,
+			wantRetry:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wait, retry := test.policy.wait(test.attempt, test.retryAfter)
+			if wait != test.want || retry != test.wantRetry {
+				t.Errorf("wait = %v, %t; want %v, %t", wait, retry, test.want, test.wantRetry)
+			}
+		})
+	}
+}
+
+func TestInvokeGivesUpOnRetryAfterBeyondMaxBackoff(t *testing.T) {
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		writer.Header().Set("Retry-After", "60")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	policy := DefaultRetryPolicy()
+	client := provideServiceClient(server.URL, "api-key", "tickets", &recordingMetrics{}, serviceOptions{retryPolicy: policy})
+	start := time.Now()
+	serviceResponse := invoke[bool](context.Background(), client, serviceCall{methodName: "Test.Fetch", action: "fetch", kind: readCall})
+	if serviceResponse.StatusCode != http.StatusServiceUnavailable || requests != 1 {
+		t.Fatalf("status %d after %d requests, want 503 after 1", serviceResponse.StatusCode, requests)
+	}
+	if elapsed := time.Since(start); elapsed > policy.MaxBackoff {
+		t.Errorf("gave up after %v, want no wait", elapsed)
+	}
+}
+
+func TestInvokeSendsTheSameIdempotencyKeyOnEveryAttempt(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		keys = append(keys, request.Header.Get("Idempotency-Key"))
+		attempt := len(keys)
+		mu.Unlock()
+		if attempt < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(writer, `{"partition_key":"pk","range_key":"rk"}`)
+	}))
+	defer server.Close()
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryableStatusCodes: []int{http.StatusServiceUnavailable}}
+	ticketService := ProvideTicketService(server.URL, "api-key", "client", "team", "autocut", &recordingMetrics{}, WithRetryPolicy(policy))
+	if _, err := ticketService.CreateAutocutWithError(context.Background(), "Disk full", "description", "", 2); err != nil {
+		t.Fatalf("CreateAutocut: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("%d attempts, want 3", len(keys))
+	}
+	if keys[0] == "" || keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("Idempotency-Key per attempt %q, want one key repeated", keys)
+	}
+}
+
+func TestInvokeRefusesAnUnsetCallKind(t *testing.T) {
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		atomic.AddInt64(&requests, 1)
+	}))
+	defer server.Close()
+	client := provideServiceClient(server.URL, "api-key", "tickets", &recordingMetrics{}, serviceOptions{})
+	serviceResponse := invoke[bool](context.Background(), client, serviceCall{methodName: "Test.Update", action: "update"})
+	if serviceResponse.StatusCode != http.StatusInternalServerError || requests != 0 {
+		t.Errorf("status %d after %d requests, want 500 before sending", serviceResponse.StatusCode, requests)
+	}
+}
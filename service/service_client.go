@@ -0,0 +1,406 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	json2 "encoding/json"
+	"errors"
+	"fmt"
+	metrics2 "github.com/nicholaspark09/awsgorocket/metrics"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/awsgorocket/utils"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// serviceClient holds what every service needs to talk to CincinnatiTicketService.
+// Requests are built with the caller's context so cancellation and deadlines reach the socket.
+type serviceClient struct {
+	endpoint       string
+	apiKey         string
+	contentType    string
+	controllerName string
+	metricsManager metrics2.MetricsManagerContract
+	httpClient     *http.Client
+	logger         *slog.Logger
+	redactor       redactor
+	tracer         trace.Tracer
+	propagator     propagation.TextMapPropagator
+	userAgent      string
+	headers        http.Header
+	retryPolicy    RetryPolicy
+	breaker        *CircuitBreaker
+}
+
+func provideServiceClient(
+	endpoint string,
+	apiKey string,
+	controllerName string,
+	metricsManager metrics2.MetricsManagerContract,
+	options serviceOptions,
+) serviceClient {
+	httpClient := options.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	redactor := newRedactor(options.piiAllowList)
+	propagator := options.propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	if options.timeout > 0 {
+		withTimeout := *httpClient
+		withTimeout.Timeout = options.timeout
+		httpClient = &withTimeout
+	}
+	return serviceClient{
+		endpoint:       endpoint,
+		apiKey:         apiKey,
+		contentType:    "application/json",
+		controllerName: controllerName,
+		metricsManager: metricsManager,
+		httpClient:     httpClient,
+		logger:         redactLogger(loggerOrDiscard(options.logger), redactor),
+		redactor:       redactor,
+		tracer:         tracerOrGlobal(options.tracerProvider),
+		propagator:     propagator,
+		userAgent:      options.userAgent,
+		headers:        options.headers,
+		retryPolicy:    options.retryPolicy,
+		breaker:        options.circuitBreaker,
+	}
+}
+
+// serviceCall describes one controller/action request. Fields are key/value pairs
+// that are attached to every log record written for the call.
+type serviceCall struct {
+	methodName string
+	// metricName defaults to methodName
+	metricName string
+	action     string
+	// kind must be set; see callKind
+	kind callKind
+	// idempotencyKey is sent with every attempt of a write; one is generated when empty
+	idempotencyKey string
+	httpMethod     string
+	params         map[string]string
+	body           any
+	fields         []any
+	// invalid rejects the call with a 400 before anything is sent, e.g. for an unknown enum value
+	invalid error
+	// omitController addresses the call by its action alone, the way autocut creates have always been sent
+	omitController bool
+}
+
+// invoke runs the call inside its own span, so the trace context sent to the service and the span's
+// status both reflect it.
+func invoke[T any](ctx context.Context, client serviceClient, call serviceCall) response.Response[T] {
+	methodName := call.methodName
+	logger := client.logger.With(append([]any{"method", methodName}, fieldAttrs(call.fields)...)...)
+	logger.DebugContext(ctx, "STARTED")
+	start := time.Now()
+
+	if call.httpMethod == "" {
+		call.httpMethod = http.MethodPost
+	}
+	ctx, span := startSpan(ctx, client, call)
+	serviceResponse := invokeInSpan[T](ctx, client, call, logger, start)
+	var spanError error
+	if serviceResponse.Error != nil {
+		spanError = *serviceResponse.Error
+	}
+	endSpan(span, serviceResponse.StatusCode, spanError)
+	if serviceResponse.StatusCode != http.StatusOK {
+		reportError(client.metricsManager, call.metric(), serviceResponse.StatusCode, serviceResponse.Message)
+	}
+	return serviceResponse
+}
+
+// reportError counts a failed call with the metrics manager, as a 5xx or a 4xx by the status code it
+// finally failed with, so each call is counted once however many attempts it took.
+func reportError(metricsManager metrics2.MetricsManagerContract, metricName string, statusCode int, message string) {
+	if statusCode >= http.StatusInternalServerError {
+		metricsManager.Send500Error(metricName, statusCode, message)
+		return
+	}
+	metricsManager.Send400Error(metricName, statusCode, message)
+}
+
+func invokeInSpan[T any](ctx context.Context, client serviceClient, call serviceCall, logger *slog.Logger, start time.Time) response.Response[T] {
+	sensitive := client.redactor.sensitiveValues(call.fields)
+	if call.kind == unsetCall {
+		err := fmt.Errorf("%s has no call kind", call.methodName)
+		logger.ErrorContext(ctx, "UNSET_CALL_KIND", "error", err)
+		return response.Response[T]{StatusCode: http.StatusInternalServerError, Message: err.Error(), Error: &err}
+	}
+	if call.invalid != nil {
+		logger.WarnContext(ctx, "INVALID_REQUEST", "error", call.invalid)
+		return response.Response[T]{StatusCode: http.StatusBadRequest, Message: call.invalid.Error(), Error: &call.invalid}
+	}
+	var bytes []byte
+	if call.httpMethod == http.MethodPost {
+		var parseError error
+		bytes, parseError = json2.Marshal(call.body)
+		if parseError != nil {
+			logger.ErrorContext(ctx, "PARSE_ERROR", "error", parseError)
+			return response.Response[T]{StatusCode: 400, Message: "Invalid request body", Error: &parseError}
+		}
+	}
+
+	// Writes carry one key for all their attempts so the service can recognise a repeat.
+	idempotencyKey := call.idempotencyKey
+	if idempotencyKey == "" && call.kind != readCall {
+		idempotencyKey = newIdempotencyKey()
+	}
+	networkResponse, networkError := metrics2.MeasureTimeWithError(call.metric(), client.metricsManager, func() (*T, *error) {
+		for attempt := 1; ; attempt++ {
+			data, statusCode, retryAfter, callErr := sendOnce[T](ctx, client, call, bytes, idempotencyKey)
+			if errors.Is(callErr, ErrCircuitOpen) {
+				logger.WarnContext(ctx, "CIRCUIT_OPEN", "attempt", attempt)
+				return nil, &callErr
+			}
+
+			logger.DebugContext(ctx, "NETWORK_RESPONSE", "status_code", statusCode, "attempt", attempt)
+
+			if callErr == nil {
+				return data, nil
+			}
+			logger.WarnContext(ctx, "NETWORK_ERROR",
+				"status_code", statusCode, "attempt", attempt, "error", client.redactor.error(callErr, sensitive...))
+
+			policy := client.retryPolicy
+			if attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.shouldRetry(call.kind, statusCode, callErr) {
+				return nil, &callErr
+			}
+			wait, ok := policy.wait(attempt, retryAfter)
+			if !ok {
+				logger.WarnContext(ctx, "RETRY_AFTER_TOO_LONG", "attempt", attempt, "retry_after", retryAfter)
+				return nil, &callErr
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return nil, &callErr
+			}
+			logger.InfoContext(ctx, "RETRY", "status_code", statusCode, "attempt", attempt, "backoff", wait)
+			trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+				attribute.Int("attempt", attempt),
+				attribute.Int("http.response.status_code", statusCode),
+				attribute.String("backoff", wait.String())))
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, &callErr
+			}
+		}
+	})
+
+	if networkError != nil {
+		// Messages and errors may quote the request, so they are redacted before anyone sees them.
+		redactedError := client.redactor.error(*networkError, sensitive...)
+		networkError = &redactedError
+		var genericError utils.GenericError
+		if errors.As(*networkError, &genericError) {
+			message := client.redactor.text(genericError.Message, sensitive...)
+			logger.Log(ctx, errorLevel(genericError.StatusCode), "GENERIC_ERROR",
+				"status_code", genericError.StatusCode, "message", message, "duration", time.Since(start))
+			return response.Response[T]{
+				StatusCode: genericError.StatusCode,
+				Message:    message,
+				Error:      networkError,
+			}
+		}
+
+		if errors.Is(*networkError, ErrCircuitOpen) {
+			return response.Response[T]{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    (*networkError).Error(),
+				Error:      networkError,
+			}
+		}
+
+		if errors.Is(*networkError, context.DeadlineExceeded) || errors.Is(*networkError, context.Canceled) {
+			statusCode := contextStatusCode(*networkError)
+			logger.WarnContext(ctx, "CONTEXT_ERROR",
+				"status_code", statusCode, "error", *networkError, "duration", time.Since(start))
+			return response.Response[T]{
+				StatusCode: statusCode,
+				Message:    (*networkError).Error(),
+				Error:      networkError,
+			}
+		}
+
+		logger.ErrorContext(ctx, "UNKNOWN_ERROR", "status_code", 500, "error", *networkError, "duration", time.Since(start))
+		return response.Response[T]{
+			StatusCode: 500,
+			Message:    "Internal service error",
+			Error:      networkError,
+		}
+	}
+
+	completed := []any{"status_code", 200, "duration", time.Since(start)}
+	if count, ok := resultCount(networkResponse); ok {
+		completed = append(completed, "result_count", count)
+	}
+	logger.InfoContext(ctx, "COMPLETED", completed...)
+	return response.Response[T]{Data: networkResponse, StatusCode: 200}
+}
+
+// errorLevel logs client errors, which are usually the caller's to fix, below server errors.
+func errorLevel(statusCode int) slog.Level {
+	if statusCode >= http.StatusInternalServerError {
+		return slog.LevelError
+	}
+	return slog.LevelWarn
+}
+
+func (call serviceCall) metric() string {
+	if call.metricName == "" {
+		return call.methodName
+	}
+	return call.metricName
+}
+
+// contextStatusCode maps a context error onto the status code reported for it: 504 for a
+// deadline and 499, the de facto "client closed request" code, for a cancellation.
+func contextStatusCode(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return 499
+}
+
+// sendOnce sends the call once, unless the circuit breaker is open, and tells the breaker how it went.
+func sendOnce[T any](ctx context.Context, client serviceClient, call serviceCall, body []byte, idempotencyKey string) (*T, int, time.Duration, error) {
+	if client.breaker == nil {
+		return send[T](ctx, client, call, body, idempotencyKey)
+	}
+	allowed, probe := client.breaker.allow()
+	if !allowed {
+		return nil, http.StatusServiceUnavailable, 0, ErrCircuitOpen
+	}
+	data, statusCode, retryAfter, err := send[T](ctx, client, call, body, idempotencyKey)
+	client.breaker.record(outcomeOf(statusCode, err), probe)
+	return data, statusCode, retryAfter, err
+}
+
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(key)
+}
+
+// send performs one HTTP exchange. Non-200 responses come back as utils.GenericError so
+// they are handled the same way the network_v2 manager reports them, along with any Retry-After.
+func send[T any](ctx context.Context, client serviceClient, call serviceCall, body []byte, idempotencyKey string) (*T, int, time.Duration, error) {
+	formedEndpoint, queryError := client.formEndpoint(call)
+	if queryError != nil {
+		return nil, 400, 0, queryError
+	}
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, call.httpMethod, formedEndpoint, bodyReader)
+	if err != nil {
+		return nil, 500, 0, err
+	}
+	for name, values := range client.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if client.userAgent != "" {
+		req.Header.Set("User-Agent", client.userAgent)
+	}
+	req.Header.Set("Content-Type", client.contentType)
+	req.Header.Set("x-api-key", client.apiKey)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	injectTraceContext(ctx, client, req.Header)
+
+	httpClient := client.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	clientResponse, err := httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, 0, ctxErr
+		}
+		return nil, 0, 0, err
+	}
+	defer clientResponse.Body.Close()
+	if clientResponse.StatusCode != http.StatusOK {
+		retryAfter := parseRetryAfter(clientResponse.Header.Get("Retry-After"), time.Now())
+		return nil, clientResponse.StatusCode, retryAfter, utils.GenericError{
+			Message:    fmt.Sprintf("Error with the request call: %d", clientResponse.StatusCode),
+			StatusCode: clientResponse.StatusCode,
+		}
+	}
+	responseBody, err := io.ReadAll(clientResponse.Body)
+	if err != nil {
+		return nil, clientResponse.StatusCode, 0, err
+	}
+	var result T
+	if jsonError := json2.Unmarshal(responseBody, &result); jsonError != nil {
+		return nil, 500, 0, jsonError
+	}
+	return &result, clientResponse.StatusCode, 0, nil
+}
+
+func (client serviceClient) formEndpoint(call serviceCall) (string, error) {
+	parsedUrl, err := url.Parse(client.endpoint)
+	if err != nil {
+		return client.endpoint, err
+	}
+	queryParams := parsedUrl.Query()
+	if !call.omitController {
+		queryParams.Set("controller", client.controllerName)
+	}
+	queryParams.Set("action", call.action)
+	for key, value := range call.params {
+		queryParams.Set(key, value)
+	}
+	parsedUrl.RawQuery = queryParams.Encode()
+	return parsedUrl.String(), nil
+}
+
+// resultCount reports how many results a list response holds.
+func resultCount(data any) (int, bool) {
+	switch typed := data.(type) {
+	case *model2.TicketModelsResponse:
+		if typed != nil {
+			return len(typed.Results), true
+		}
+	case *model2.TicketCommentModelsResponse:
+		if typed != nil {
+			return len(typed.Results), true
+		}
+	case *model2.TicketWatchModelsResponse:
+		if typed != nil {
+			return len(typed.Results), true
+		}
+	case *model2.TicketTeamModelsResponse:
+		if typed != nil {
+			return len(typed.Results), true
+		}
+	case *model2.TicketTeamMemberModelsResponse:
+		if typed != nil {
+			return len(typed.Results), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,518 @@
+package service
+
+import (
+	"context"
+	json2 "encoding/json"
+	"errors"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_member_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// capturedRequest is what the test server saw of a request.
+type capturedRequest struct {
+	method string
+	query  map[string]string
+	header http.Header
+	body   []byte
+}
+
+// newCapturingServer answers every request with reply and keeps the last request.
+func newCapturingServer(t *testing.T, reply string) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	captured := &capturedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Errorf("reading the request body: %v", err)
+		}
+		captured.method = request.Method
+		captured.query = map[string]string{}
+		for name, values := range request.URL.Query() {
+			captured.query[name] = values[0]
+		}
+		captured.header = request.Header.Clone()
+		captured.body = body
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(writer, reply)
+	}))
+	t.Cleanup(server.Close)
+	return server, captured
+}
+
+func TestServiceRequestShapes(t *testing.T) {
+	lastKey := "last"
+	replyKey := "rk"
+	tickets := func(endpoint string) *TicketService {
+		ticketService := ProvideTicketService(endpoint, "api-key", "client", "team", "autocut", &recordingMetrics{})
+		return &ticketService
+	}
+	comments := func(endpoint string) *TicketCommentService {
+		commentService := ProvideTicketCommentService(endpoint, "api-key", &recordingMetrics{})
+		return &commentService
+	}
+	watches := func(endpoint string) *TicketWatchService {
+		watchService := ProvideTicketWatchService(endpoint, "api-key", &recordingMetrics{})
+		return &watchService
+	}
+	teams := func(endpoint string) *TicketTeamService {
+		teamService := ProvideTicketTeamService(endpoint, "api-key", &recordingMetrics{})
+		return &teamService
+	}
+	members := func(endpoint string) *TicketTeamMemberService {
+		memberService := ProvideTicketTeamMemberService(endpoint, "api-key", &recordingMetrics{})
+		return &memberService
+	}
+	tests := []struct {
+		name  string
+		reply string
+		call  func(ctx context.Context, endpoint string) (any, error)
+		// wantQuery is every query parameter sent.
+		wantQuery map[string]string
+		// wantBody holds the body fields checked; nil means no body is sent.
+		wantBody string
+		// write calls send an Idempotency-Key.
+		write bool
+		// want is the decoded result, when the call returns one.
+		want any
+	}{
+		{
+			name:  "TicketService.CreateAutocut",
+			reply: `{"partition_key":"client_team","range_key":"t-1","title":"Disk full"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return tickets(endpoint).CreateAutocutWithError(ctx, "Disk full", "description", "files", 2)
+			},
+			wantQuery: map[string]string{"action": "create"},
+			wantBody: `{"client_id":"client","team_range_key":"team","title":"Disk full","description":"description",
+				"files":"files","severity":2,"user_id":"autocut","status":"OPEN"}`,
+			write: true,
+			want:  &model.TicketModel{PartitionKey: "client_team", RangeKey: "t-1", Title: "Disk full"},
+		},
+		{
+			name:  "TicketService.Fetch",
+			reply: `{"partition_key":"pk","range_key":"rk","severity":3}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return tickets(endpoint).FetchWithError(ctx, "pk", "rk")
+			},
+			wantQuery: map[string]string{"controller": "tickets", "action": "fetch"},
+			wantBody:  `{"partition_key":"pk","range_key":"rk","user_id":""}`,
+			want:      &model.TicketModel{PartitionKey: "pk", RangeKey: "rk", Severity: 3},
+		},
+		{
+			name:  "TicketService.FetchAll",
+			reply: `{"results":[{"partition_key":"pk","range_key":"rk"}],"last_range_key":"rk"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return tickets(endpoint).FetchAllWithError(ctx, ticket_model_request.TicketModelFetchAllRequest{
+					ClientId: "client", TeamId: "team", UserId: "u-1", LastRangeKey: &lastKey,
+				})
+			},
+			wantQuery: map[string]string{"controller": "tickets", "action": "fetchAll"},
+			wantBody:  `{"client_id":"client","team_id":"team","user_id":"u-1","last_range_key":"last"}`,
+			want: &model.TicketModelsResponse{
+				Results:      []*model.TicketModel{{PartitionKey: "pk", RangeKey: "rk"}},
+				LastRangeKey: &replyKey,
+			},
+		},
+		{
+			name:  "TicketService.FetchByUser",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return tickets(endpoint).FetchByUserWithError(ctx, ticket_model_request.TicketModelByUserRequest{UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "tickets", "action": "fetchByUser"},
+			wantBody:  `{"user_id":"u-1","last_partition_key":null,"last_range_key":null}`,
+			want:      &model.TicketModelsResponse{Results: []*model.TicketModel{}},
+		},
+		{
+			name:  "TicketService.Update",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, tickets(endpoint).UpdateWithError(ctx, "u-1", model.TicketModel{PartitionKey: "pk", RangeKey: "rk", Status: "CLOSED"})
+			},
+			wantQuery: map[string]string{"controller": "tickets", "action": "update"},
+			wantBody:  `{"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketService.Delete",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, tickets(endpoint).DeleteWithError(ctx, model.DeleteRequest{PartitionKey: "pk", RangeKey: "rk", IsHardDelete: true, UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "tickets", "action": "delete"},
+			wantBody:  `{"partition_key":"pk","range_key":"rk","is_hard_delete":true,"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketCommentService.Create",
+			reply: `{"partition_key":"pk","range_key":"c-1","message":"on it"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return comments(endpoint).CreateWithError(ctx, ticket_comment_request.TicketCommentModelCreateRequest{
+					TicketPartitionKey: "pk", TicketRangeKey: "rk", UserId: "u-1", Message: "on it", IdempotencyKey: "key-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "create"},
+			wantBody: `{"ticket_partition_key":"pk","ticket_range_key":"rk","user_id":"u-1","message":"on it","files":"",
+				"idempotency_key":"key-1"}`,
+			write: true,
+			want:  &model.TicketCommentModel{PartitionKey: "pk", RangeKey: "c-1", Message: "on it"},
+		},
+		{
+			name:  "TicketCommentService.FetchAll",
+			reply: `{"results":[{"partition_key":"pk","range_key":"c-1"}]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return comments(endpoint).FetchAllWithError(ctx, ticket_comment_request.TicketCommentModelFetchAllRequest{
+					TicketPartitionKey: "pk", TicketRangeKey: "rk", UserId: "u-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "fetchAll"},
+			wantBody:  `{"ticket_partition_key":"pk","ticket_range_key":"rk","user_id":"u-1","last_range_key":null}`,
+			want:      &model.TicketCommentModelsResponse{Results: []*model.TicketCommentModel{{PartitionKey: "pk", RangeKey: "c-1"}}},
+		},
+		{
+			name:  "TicketCommentService.Fetch",
+			reply: `{"partition_key":"pk","range_key":"c-1"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return comments(endpoint).FetchWithError(ctx, "pk", "c-1", "u-1")
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "fetch"},
+			wantBody:  `{"partition_key":"pk","range_key":"c-1","user_id":"u-1"}`,
+			want:      &model.TicketCommentModel{PartitionKey: "pk", RangeKey: "c-1"},
+		},
+		{
+			name:  "TicketCommentService.Update",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, comments(endpoint).UpdateWithError(ctx, ticket_comment_request.TicketCommentModelUpdateRequest{
+					UserId: "u-1", Comment: model.TicketCommentModel{PartitionKey: "pk", RangeKey: "c-1"},
+				})
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "update"},
+			wantBody:  `{"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketCommentService.Delete",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, comments(endpoint).DeleteWithError(ctx, model.DeleteRequest{PartitionKey: "pk", RangeKey: "c-1", UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "delete"},
+			wantBody:  `{"partition_key":"pk","range_key":"c-1","is_hard_delete":false,"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketCommentService.FetchByUser",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return comments(endpoint).FetchByUserWithError(ctx, ticket_comment_request.TicketCommentModelByUserRequest{UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "ticket-comments", "action": "fetchByUser"},
+			wantBody:  `{"user_id":"u-1"}`,
+			want:      &model.TicketCommentModelsResponse{Results: []*model.TicketCommentModel{}},
+		},
+		{
+			name:  "TicketWatchService.AddWatcher",
+			reply: `{"partition_key":"u-1","range_key":"pk_rk"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return watches(endpoint).AddWatcherWithError(ctx, ticket_watch_request.TicketWatchAddRequest{
+					UserId: "u-1", TicketPartitionKey: "pk", TicketRangeKey: "rk", Role: string(model.WatchRoleAssignee),
+					IdempotencyKey: "key-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "addWatcher"},
+			wantBody: `{"user_id":"u-1","ticket_partition_key":"pk","ticket_range_key":"rk","role":"ASSIGNEE",
+				"idempotency_key":"key-1"}`,
+			write: true,
+			want:  &model.TicketWatchModel{PartitionKey: "u-1", RangeKey: "pk_rk"},
+		},
+		{
+			name:  "TicketWatchService.RemoveWatcher",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, watches(endpoint).RemoveWatcherWithError(ctx, ticket_watch_request.TicketWatchRemoveRequest{
+					UserId: "u-1", PartitionKey: "u-1", RangeKey: "pk_rk",
+				})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "removeWatcher"},
+			wantBody:  `{"user_id":"u-1","partition_key":"u-1","range_key":"pk_rk"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketWatchService.GetUserWatchList",
+			reply: `{"results":[{"partition_key":"u-1","range_key":"pk_rk","unread_updates":2}]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return watches(endpoint).GetUserWatchListWithError(ctx, ticket_watch_request.TicketWatchUserListRequest{
+					UserId: "u-1", LastRangeKey: &lastKey,
+				})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "getUserWatchList", "userId": "u-1", "lastRangeKey": "last"},
+			want: &model.TicketWatchModelsResponse{
+				Results: []*model.TicketWatchModel{{PartitionKey: "u-1", RangeKey: "pk_rk", UnreadUpdates: 2}},
+			},
+		},
+		{
+			name:  "TicketWatchService.GetUserUnreadList",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return watches(endpoint).GetUserUnreadListWithError(ctx, ticket_watch_request.TicketWatchUserListRequest{UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "getUserUnreadList", "userId": "u-1"},
+			want:      &model.TicketWatchModelsResponse{Results: []*model.TicketWatchModel{}},
+		},
+		{
+			name:  "TicketWatchService.GetTicketWatchers",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return watches(endpoint).GetTicketWatchersWithError(ctx, ticket_watch_request.TicketWatchersListRequest{
+					TicketPartitionKey: "pk", TicketRangeKey: "rk", UserId: "u-1", LastPartitionKey: &lastKey, LastRangeKey: &lastKey,
+				})
+			},
+			wantQuery: map[string]string{
+				"controller": "watchers", "action": "getTicketWatchers",
+				"ticketPK": "pk", "ticketRK": "rk", "userId": "u-1", "lastPartitionKey": "last", "lastRangeKey": "last",
+			},
+			want: &model.TicketWatchModelsResponse{Results: []*model.TicketWatchModel{}},
+		},
+		{
+			name:  "TicketWatchService.MarkAsRead",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, watches(endpoint).MarkAsReadWithError(ctx, ticket_watch_request.TicketWatchMarkReadRequest{
+					UserId: "u-1", TicketPartitionKey: "pk", TicketRangeKey: "rk",
+				})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "markAsRead"},
+			wantBody:  `{"user_id":"u-1","ticket_partition_key":"pk","ticket_range_key":"rk"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketWatchService.UpdateWatchEntry",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, watches(endpoint).UpdateWatchEntryWithError(ctx, ticket_watch_request.TicketWatchUpdateRequest{
+					UserId: "u-1", TicketPartitionKey: "pk", TicketRangeKey: "rk", TicketTitle: "Disk full",
+					TicketStatus: string(model.TicketStatusResolved), LastUpdated: "2024-01-02T03:04:05Z",
+				})
+			},
+			wantQuery: map[string]string{"controller": "watchers", "action": "updateWatchEntry"},
+			wantBody: `{"user_id":"u-1","ticket_partition_key":"pk","ticket_range_key":"rk","ticket_title":"Disk full",
+				"ticket_status":"RESOLVED","last_updated":"2024-01-02T03:04:05Z"}`,
+			write: true,
+		},
+		{
+			name:  "TicketTeamService.Create",
+			reply: `{"partition_key":"client","range_key":"team","title":"Ops"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return teams(endpoint).CreateWithError(ctx, ticket_team_model_request.TicketTeamModelCreateRequest{
+					ClientId: "client", Title: "Ops", UserId: "u-1", IdempotencyKey: "key-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "teams", "action": "create"},
+			wantBody:  `{"client_id":"client","title":"Ops","user_id":"u-1","idempotency_key":"key-1"}`,
+			write:     true,
+			want:      &model.TicketTeamModel{PartitionKey: "client", RangeKey: "team", Title: "Ops"},
+		},
+		{
+			name:  "TicketTeamService.Update",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, teams(endpoint).UpdateWithError(ctx, "u-1", model.TicketTeamModel{PartitionKey: "client", RangeKey: "team"})
+			},
+			wantQuery: map[string]string{"controller": "teams", "action": "update"},
+			wantBody:  `{"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketTeamService.Fetch",
+			reply: `{"partition_key":"client","range_key":"team"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return teams(endpoint).FetchWithError(ctx, "client", "team", "u-1")
+			},
+			wantQuery: map[string]string{"controller": "teams", "action": "fetch"},
+			wantBody:  `{"partition_key":"client","range_key":"team","user_id":"u-1"}`,
+			want:      &model.TicketTeamModel{PartitionKey: "client", RangeKey: "team"},
+		},
+		{
+			name:  "TicketTeamService.FetchAll",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return teams(endpoint).FetchAllWithError(ctx, "client", &lastKey)
+			},
+			wantQuery: map[string]string{"controller": "teams", "action": "fetchAll"},
+			wantBody:  `{"client_id":"client","last_range_key":"last"}`,
+			want:      &model.TicketTeamModelsResponse{Results: []*model.TicketTeamModel{}},
+		},
+		{
+			name:  "TicketTeamService.Delete",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, teams(endpoint).DeleteWithError(ctx, model.DeleteRequest{PartitionKey: "client", RangeKey: "team", UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "teams", "action": "delete"},
+			wantBody:  `{"partition_key":"client","range_key":"team","user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketTeamMemberService.Create",
+			reply: `{"partition_key":"team","range_key":"m-1","title":"SRE"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return members(endpoint).CreateWithError(ctx, ticket_team_member_model_request.TicketTeamMemberModelCreateRequest{
+					ClientId: "client", TicketTeamId: "team", Title: "SRE", Email: "jane@example.com", UserId: "u-1",
+					Level: int(model.MemberLevelAdmin), IdempotencyKey: "key-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "create"},
+			wantBody: `{"client_id":"client","ticket_team_id":"team","title":"SRE","email":"jane@example.com","user_id":"u-1",
+				"level":5,"idempotency_key":"key-1"}`,
+			write: true,
+			want:  &model.TicketTeamMemberModel{PartitionKey: "team", RangeKey: "m-1", Title: "SRE"},
+		},
+		{
+			name:  "TicketTeamMemberService.Update",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, members(endpoint).UpdateWithError(ctx, "u-1", model.TicketTeamMemberModel{PartitionKey: "team", RangeKey: "m-1"})
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "update"},
+			wantBody:  `{"user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketTeamMemberService.Delete",
+			reply: `true`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return nil, members(endpoint).DeleteWithError(ctx, model.DeleteRequest{PartitionKey: "team", RangeKey: "m-1", UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "delete"},
+			wantBody:  `{"partition_key":"team","range_key":"m-1","user_id":"u-1"}`,
+			write:     true,
+		},
+		{
+			name:  "TicketTeamMemberService.FetchAll",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return members(endpoint).FetchAllWithError(ctx, ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest{
+					ClientId: "client", TicketTeamId: "team", UserId: "u-1",
+				})
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "fetchAll"},
+			wantBody:  `{"client_id":"client","ticket_team_id":"team","user_id":"u-1","last_range_key":null}`,
+			want:      &model.TicketTeamMemberModelsResponse{Results: []*model.TicketTeamMemberModel{}},
+		},
+		{
+			name:  "TicketTeamMemberService.FetchByUser",
+			reply: `{"results":[]}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return members(endpoint).FetchByUserWithError(ctx, ticket_team_member_model_request.TicketTeamMemberByUserRequest{UserId: "u-1"})
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "fetchByUser"},
+			wantBody:  `{"user_id":"u-1"}`,
+			want:      &model.TicketTeamMemberModelsResponse{Results: []*model.TicketTeamMemberModel{}},
+		},
+		{
+			name:  "TicketTeamMemberService.Fetch",
+			reply: `{"partition_key":"team","range_key":"m-1"}`,
+			call: func(ctx context.Context, endpoint string) (any, error) {
+				return members(endpoint).FetchWithError(ctx, "jane@example.com", "team", "m-1")
+			},
+			wantQuery: map[string]string{"controller": "teammembers", "action": "fetch"},
+			// The member's email goes out as the user id.
+			wantBody: `{"partition_key":"team","range_key":"m-1","user_id":"jane@example.com"}`,
+			want:     &model.TicketTeamMemberModel{PartitionKey: "team", RangeKey: "m-1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, captured := newCapturingServer(t, test.reply)
+			got, err := test.call(context.Background(), server.URL)
+			if err != nil {
+				t.Fatalf("call: %v", err)
+			}
+			wantMethod := http.MethodPost
+			if test.wantBody == "" {
+				wantMethod = http.MethodGet
+			}
+			if captured.method != wantMethod {
+				t.Errorf("method %s, want %s", captured.method, wantMethod)
+			}
+			if !reflect.DeepEqual(captured.query, test.wantQuery) {
+				t.Errorf("query %v, want %v", captured.query, test.wantQuery)
+			}
+			if apiKey := captured.header.Get("x-api-key"); apiKey != "api-key" {
+				t.Errorf("x-api-key %q, want api-key", apiKey)
+			}
+			if contentType := captured.header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Content-Type %q, want application/json", contentType)
+			}
+			checkBody(t, captured.body, test.wantBody)
+			idempotencyKey := captured.header.Get("Idempotency-Key")
+			if (idempotencyKey != "") != test.write {
+				t.Errorf("Idempotency-Key %q, want one %t", idempotencyKey, test.write)
+			}
+			var body map[string]any
+			_ = json2.Unmarshal(captured.body, &body)
+			if bodyKey, ok := body["idempotency_key"]; ok && bodyKey != idempotencyKey {
+				t.Errorf("Idempotency-Key %q differs from the body's %q", idempotencyKey, bodyKey)
+			}
+			if test.want != nil && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("decoded %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// checkBody compares the fields of want, a JSON object, with the same fields of body.
+func checkBody(t *testing.T, body []byte, want string) {
+	t.Helper()
+	if want == "" {
+		if len(body) != 0 {
+			t.Errorf("body %s, want none", body)
+		}
+		return
+	}
+	var got, wantFields map[string]any
+	if err := json2.Unmarshal(body, &got); err != nil {
+		t.Fatalf("body %s is not a JSON object: %v", body, err)
+	}
+	if err := json2.Unmarshal([]byte(want), &wantFields); err != nil {
+		t.Fatalf("want %s is not a JSON object: %v", want, err)
+	}
+	for name, value := range wantFields {
+		if gotValue, ok := got[name]; !ok || !reflect.DeepEqual(gotValue, value) {
+			t.Errorf("body field %s = %v, want %v", name, gotValue, value)
+		}
+	}
+}
+
+func TestInvokeDecodeFailures(t *testing.T) {
+	tests := []struct {
+		name           string
+		reply          string
+		wantStatusCode int
+		wantMatches    error
+	}{
+		{name: "not JSON", reply: `<html>`, wantStatusCode: http.StatusInternalServerError, wantMatches: ErrUnavailable},
+		{name: "wrong shape", reply: `[1, 2]`, wantStatusCode: http.StatusInternalServerError, wantMatches: ErrUnavailable},
+		{name: "empty body", reply: ``, wantStatusCode: http.StatusInternalServerError, wantMatches: ErrUnavailable},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _ := newCapturingServer(t, test.reply)
+			ticketService := ProvideTicketService(server.URL, "api-key", "client", "team", "autocut", &recordingMetrics{})
+			_, err := ticketService.FetchWithError(context.Background(), "pk", "rk")
+			var requestError *RequestError
+			if !errors.As(err, &requestError) || requestError.StatusCode != test.wantStatusCode || !errors.Is(err, test.wantMatches) {
+				t.Errorf("Fetch = %v, want a %d matching %v", err, test.wantStatusCode, test.wantMatches)
+			}
+		})
+	}
+}
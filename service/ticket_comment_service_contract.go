@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+)
+
+type TicketCommentServiceContract interface {
+	Create(createRequest ticket_comment_request.TicketCommentModelCreateRequest) response.Response[model2.TicketCommentModel]
+	CreateCtx(ctx context.Context, createRequest ticket_comment_request.TicketCommentModelCreateRequest) response.Response[model2.TicketCommentModel]
+	CreateWithError(ctx context.Context, createRequest ticket_comment_request.TicketCommentModelCreateRequest) (*model2.TicketCommentModel, error)
+	FetchAll(fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) response.Response[model2.TicketCommentModelsResponse]
+	FetchAllCtx(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) response.Response[model2.TicketCommentModelsResponse]
+	FetchAllWithError(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) (*model2.TicketCommentModelsResponse, error)
+	Fetch(partitionKey string, rangeKey string, userId string) response.Response[model2.TicketCommentModel]
+	FetchCtx(ctx context.Context, partitionKey string, rangeKey string, userId string) response.Response[model2.TicketCommentModel]
+	FetchWithError(ctx context.Context, partitionKey string, rangeKey string, userId string) (*model2.TicketCommentModel, error)
+	Update(updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) response.Response[bool]
+	UpdateCtx(ctx context.Context, updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) response.Response[bool]
+	UpdateWithError(ctx context.Context, updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) error
+	Delete(deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error
+	FetchByUser(fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) response.Response[model2.TicketCommentModelsResponse]
+	FetchByUserCtx(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) response.Response[model2.TicketCommentModelsResponse]
+	FetchByUserWithError(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) (*model2.TicketCommentModelsResponse, error)
+}
+
+var _ TicketCommentServiceContract = (*TicketCommentService)(nil)
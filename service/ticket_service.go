@@ -1,16 +1,14 @@
 package service
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"fmt"
 	"github.com/nicholaspark09/awsgorocket/metrics"
 	response "github.com/nicholaspark09/awsgorocket/model"
-	network2 "github.com/nicholaspark09/awsgorocket/network"
-	"github.com/nicholaspark09/awsgorocket/network_v2"
-	"github.com/nicholaspark09/awsgorocket/utils"
 	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
 	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
-	"log"
+	"time"
 )
 
 type TicketService struct {
@@ -21,6 +19,15 @@ type TicketService struct {
 	TeamId         string
 	AutoCutKey     string
 	metricsManager metrics.MetricsManagerContract
+	client         serviceClient
+	deduplicator   *AutocutDeduplicator
+	limiter        *AutocutLimiter
+	spool          *AutocutSpool
+	commentService TicketCommentServiceContract
+	// fetchConcurrency bounds the worker pool of FetchMany.
+	fetchConcurrency int
+	transitioner     *TicketTransitioner
+	watchService     TicketWatchServiceContract
 }
 
 func ProvideTicketService(
@@ -30,15 +37,38 @@ func ProvideTicketService(
 	teamId string,
 	autoCutKey string,
 	metricsManager metrics.MetricsManagerContract,
+	opts ...Option,
 ) TicketService {
+	options := applyOptions(opts)
+	var deduplicator *AutocutDeduplicator
+	if options.deduplicationWindow > 0 && options.commentService != nil {
+		deduplicator = NewAutocutDeduplicator(options.deduplicationWindow)
+	}
+	fetchConcurrency := DefaultFetchConcurrency
+	if options.fetchConcurrency > 0 {
+		fetchConcurrency = options.fetchConcurrency
+	}
+	client := provideServiceClient(endpoint, apiKey, "tickets", metricsManager, options)
+	var limiter *AutocutLimiter
+	if options.autocutRateLimit != nil {
+		limiter = NewAutocutLimiter(*options.autocutRateLimit)
+	}
 	return TicketService{
-		Endpoint:       endpoint,
-		ApiKey:         apiKey,
-		ClientId:       clientId,
-		ContentType:    "application/json",
-		TeamId:         teamId,
-		AutoCutKey:     autoCutKey,
-		metricsManager: metricsManager,
+		Endpoint:         endpoint,
+		ApiKey:           apiKey,
+		ClientId:         clientId,
+		ContentType:      "application/json",
+		TeamId:           teamId,
+		AutoCutKey:       autoCutKey,
+		metricsManager:   metricsManager,
+		client:           client,
+		deduplicator:     deduplicator,
+		limiter:          limiter,
+		spool:            options.autocutSpool,
+		commentService:   options.commentService,
+		fetchConcurrency: fetchConcurrency,
+		transitioner:     NewTicketTransitioner(options.ticketTransitions, fetchConcurrency, client.logger),
+		watchService:     options.watchService,
 	}
 }
 
@@ -48,69 +78,298 @@ func (ticketService *TicketService) CreateAutocut(
 	files string,
 	severity int,
 ) bool {
-	params := map[string]string{
-		"action": "create",
-	}
-	manager := network_v2.ProvideNetworkManagerV2[model.TicketModel](ticketService.Endpoint, params, &ticketService.ApiKey, &ticketService.ContentType)
-	bytes, err := json.Marshal(ticket_model_request.TicketModelCreateRequest{
-		ClientId:     ticketService.ClientId,
-		TeamRangeKey: ticketService.TeamId,
-		Title:        title,
-		Description:  description,
-		Files:        files,
-		Severity:     severity,
-		UserId:       ticketService.AutoCutKey,
-		Status:       "OPEN",
-	})
+	return ticketService.CreateAutocutCtx(context.Background(), title, description, files, severity)
+}
+
+func (ticketService *TicketService) CreateAutocutCtx(
+	ctx context.Context,
+	title string,
+	description string,
+	files string,
+	severity int,
+) bool {
+	_, err := ticketService.CreateAutocutWithError(ctx, title, description, files, severity)
+	return err == nil
+}
+
+// CreateAutocutWithError is CreateAutocutCtx returning the ticket and a *RequestError instead of a bool.
+// When deduplication suppresses the autocut, the ticket it was folded into is returned.
+func (ticketService *TicketService) CreateAutocutWithError(
+	ctx context.Context,
+	title string,
+	description string,
+	files string,
+	severity int,
+) (*model.TicketModel, error) {
+	if ticketService.deduplicator == nil {
+		if err := ticketService.allowAutocut(ctx, severity); err != nil {
+			return nil, err
+		}
+		createResponse := ticketService.createAutocut(ctx, title, description, files, severity)
+		return createResponse.Data, ResponseError(createResponse)
+	}
+	fingerprint := AutocutFingerprint(title, description, severity, ticketService.TeamId)
+	occurrence, duplicate, err := ticketService.deduplicator.claim(ctx, fingerprint)
 	if err != nil {
-		log.Printf("TicketService.CreateFailure - Error in converting request to json: %s", err.Error())
-		return false
-	}
-	networkResponse, _ := metrics.MeasureTimeWithError("CincinnatiTicketService.create", ticketService.metricsManager, func() (*model.TicketModel, *error) {
-		callResponse, callErr := network_v2.Post[model.TicketModel](manager, bytes)
-		if callErr != nil {
-			log.Printf("TicketService.CreateFailure - Error in calling ticket service: %s", callErr)
-			return nil, &callErr
+		ticketService.client.logger.WarnContext(ctx, "CONTEXT_ERROR",
+			"method", "TicketService.CreateAutocut", "error", err, "fingerprint", fingerprint)
+		return nil, &RequestError{Kind: ErrorKindTransport, StatusCode: contextStatusCode(err), Message: err.Error(), Err: err}
+	}
+	if duplicate {
+		ticketService.appendOccurrence(ctx, fingerprint, occurrence, description, files)
+		return &occurrence.ticket, nil
+	}
+	// Duplicates are folded in above without touching the rate budget; only new tickets spend it.
+	if err := ticketService.allowAutocut(ctx, severity); err != nil {
+		ticketService.deduplicator.release(fingerprint)
+		return nil, err
+	}
+	createResponse := ticketService.createAutocut(ctx, title, description, files, severity)
+	if createResponse.Data == nil {
+		ticketService.deduplicator.release(fingerprint)
+		return nil, ResponseError(createResponse)
+	}
+	ticketService.deduplicator.record(fingerprint, *createResponse.Data)
+	return createResponse.Data, nil
+}
+
+// allowAutocut takes a token from the limiter, if any, and returns a throttling *RequestError when none is left.
+func (ticketService *TicketService) allowAutocut(ctx context.Context, severity int) error {
+	if ticketService.limiter == nil {
+		return nil
+	}
+	allowed, dropped := ticketService.limiter.Allow(ctx, severity)
+	if allowed {
+		return nil
+	}
+	message := fmt.Sprintf("Autocut throttled, Severity: %d, Dropped: %d", severity, dropped)
+	ticketService.client.logger.WarnContext(ctx, "THROTTLED",
+		"method", "TicketService.CreateAutocut", "severity", severity, "dropped", dropped)
+	ticketService.metricsManager.Send400Error("CincinnatiTicketService.create", 429, message)
+	return &RequestError{Kind: ErrorKindThrottling, StatusCode: 429, Message: message}
+}
+
+func (ticketService *TicketService) createAutocut(
+	ctx context.Context,
+	title string,
+	description string,
+	files string,
+	severity int,
+) response.Response[model.TicketModel] {
+	createRequest := ticket_model_request.TicketModelCreateRequest{
+		ClientId:       ticketService.ClientId,
+		TeamRangeKey:   ticketService.TeamId,
+		Title:          title,
+		Description:    description,
+		Files:          files,
+		Severity:       severity,
+		UserId:         ticketService.AutoCutKey,
+		Status:         string(model.TicketStatusOpen),
+		IdempotencyKey: newIdempotencyKey(),
+	}
+	LinkTrace(ctx, &createRequest)
+	createResponse := ticketService.sendAutocut(ctx, createRequest)
+	if createResponse.Data == nil && ticketService.spool != nil && spoolable(createResponse.StatusCode) {
+		if err := ticketService.spool.Store(createRequest); err != nil {
+			ticketService.client.logger.ErrorContext(ctx, "SPOOL_ERROR",
+				"method", "TicketService.CreateAutocut", "error", err, "severity", severity)
+		} else {
+			ticketService.client.logger.InfoContext(ctx, "SPOOLED",
+				"method", "TicketService.CreateAutocut", "status_code", createResponse.StatusCode, "severity", severity)
 		}
-		return callResponse, nil
-	})
-	if networkResponse != nil {
-		log.Printf("TicketService.CreateSuccess - Successfully created a ticket with PK: %s, RK: %s", networkResponse.PartitionKey, networkResponse.RangeKey)
-	} else {
-		log.Printf("TicketService.CreateFailure - Failed to create a ticket with PK: %s, RK: %s", networkResponse.PartitionKey, networkResponse.RangeKey)
 	}
-	return networkResponse != nil
+	return createResponse
 }
 
-func (ticketService *TicketService) Fetch(partitionKey string, rangeKey string) response.Response[model.TicketModel] {
-	params := map[string]string{
-		"controller": "tickets",
-		"action":     "fetch",
-	}
-	manager := network2.ProvideNetworkManager[model.TicketModel](ticketService.Endpoint, params, &ticketService.ApiKey, &ticketService.ContentType)
-	bytes, parseError := json.Marshal(model.FetchRequest{
-		PartitionKey: partitionKey,
-		RangeKey:     rangeKey,
-		UserId:       "",
+// sendAutocut posts createRequest as is. Its idempotency key makes retries and spool replays safe.
+// It is also where the severity is checked, so direct, queued and replayed autocuts are each rejected
+// and reported once.
+func (ticketService *TicketService) sendAutocut(
+	ctx context.Context,
+	createRequest ticket_model_request.TicketModelCreateRequest,
+) response.Response[model.TicketModel] {
+	return invoke[model.TicketModel](ctx, ticketService.client, serviceCall{
+		methodName:     "TicketService.CreateAutocut",
+		metricName:     "CincinnatiTicketService.create",
+		action:         "create",
+		omitController: true,
+		kind:           idempotentWriteCall,
+		idempotencyKey: createRequest.IdempotencyKey,
+		body:           createRequest,
+		invalid:        model.Severity(createRequest.Severity).Validate(),
+		fields: []any{
+			"client_id", createRequest.ClientId,
+			"team_id", createRequest.TeamRangeKey,
+			"severity", createRequest.Severity,
+		},
 	})
-	if parseError != nil {
-		log.Printf("TicketService.FetchAllFailure - Failed to parse FetchRequest properly: %v", parseError)
-		return response.Response[model.TicketModel]{StatusCode: 400, Message: "Incorrect body"}
-	}
-	networkResponse, networkError := metrics.MeasureTimeWithError("TicketService.FetchAll", ticketService.metricsManager, func() (*model.TicketModel, *error) {
-		callResponse := network2.Post[model.TicketModel](manager, bytes)
-		if callResponse.StatusCode != 200 {
-			log.Printf("TicketService.FetchFailure - StatusCode: %v, Failed to fetch podmembers because: %v", callResponse.StatusCode, callResponse.Error)
-			return nil, callResponse.Error
-		} else {
-			log.Printf("TicketService.FetchSuccess - StatusCode: %v, Successfully fetched for PK: %s, RK: %s", callResponse.StatusCode, partitionKey, rangeKey)
+}
+
+// RunAutocutSpool replays autocuts spooled by WithAutocutSpool until ctx is done. It returns
+// immediately when no spool is configured. Replays wait for the autocut rate limit, if any, so a
+// recovering service is not hit with the whole backlog at once.
+func (ticketService *TicketService) RunAutocutSpool(ctx context.Context) {
+	if ticketService.spool == nil {
+		return
+	}
+	ticketService.spool.Run(ctx, func(ctx context.Context, createRequest ticket_model_request.TicketModelCreateRequest) int {
+		if ticketService.limiter != nil {
+			if err := ticketService.limiter.Wait(ctx, createRequest.Severity); err != nil {
+				return contextStatusCode(err)
+			}
 		}
-		return callResponse.Data, nil
+		return ticketService.sendAutocut(ctx, createRequest).StatusCode
 	})
-	var genericError utils.GenericError
-	if networkError != nil && errors.As(*networkError, &genericError) {
-		log.Printf("TicketService.FetchFailure - Failed to fetch any podmembers. Error: %v", networkError)
-		return response.Response[model.TicketModel]{StatusCode: genericError.StatusCode, Message: genericError.Message}
+}
+
+// appendOccurrence records a suppressed duplicate autocut as a comment on the ticket opened for it.
+// Only the occurrence marked by the deduplicator comments, covering every repeat since the previous
+// comment. The autocut is reported as handled even if the comment fails since the ticket already exists.
+func (ticketService *TicketService) appendOccurrence(
+	ctx context.Context,
+	fingerprint string,
+	occurrence autocutOccurrence,
+	description string,
+	files string,
+) {
+	logger := ticketService.client.logger.With("method", "TicketService.CreateAutocut",
+		"pk", occurrence.ticket.PartitionKey, "rk", occurrence.ticket.RangeKey,
+		"occurrences", occurrence.count, "fingerprint", fingerprint)
+	logger.InfoContext(ctx, "DUPLICATE")
+	if !occurrence.comment {
+		return
 	}
-	return response.Response[model.TicketModel]{Data: networkResponse, StatusCode: 200}
+	occurrences := fmt.Sprintf("occurrence %d", occurrence.count)
+	if first := occurrence.commented + 1; first < occurrence.count {
+		occurrences = fmt.Sprintf("occurrences %d-%d", first, occurrence.count)
+	}
+	_, err := ticketService.commentService.CreateWithError(ctx, ticket_comment_request.TicketCommentModelCreateRequest{
+		TicketPartitionKey: occurrence.ticket.PartitionKey,
+		TicketRangeKey:     occurrence.ticket.RangeKey,
+		UserId:             ticketService.AutoCutKey,
+		Message: fmt.Sprintf("Autocut repeated: %s since %s\n\n%s%s",
+			occurrences, occurrence.firstSeen.UTC().Format(time.RFC3339), description, traceNote(TraceIdFromContext(ctx))),
+		Files: files,
+	})
+	if err != nil {
+		logger.WarnContext(ctx, "OCCURRENCE_ERROR", "error", err)
+	}
+}
+
+func (ticketService *TicketService) Fetch(partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	return ticketService.FetchCtx(context.Background(), partitionKey, rangeKey)
+}
+
+func (ticketService *TicketService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	return invoke[model.TicketModel](ctx, ticketService.client, serviceCall{
+		methodName: "TicketService.Fetch",
+		// FetchAll is the name this call has always been measured under.
+		metricName: "TicketService.FetchAll",
+		action:     "fetch",
+		kind:       readCall,
+		body: model.FetchRequest{
+			PartitionKey: partitionKey,
+			RangeKey:     rangeKey,
+			UserId:       "",
+		},
+		fields: []any{"pk", partitionKey, "rk", rangeKey},
+	})
+}
+
+func (ticketService *TicketService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error) {
+	return result(ticketService.FetchCtx(ctx, partitionKey, rangeKey))
+}
+
+func (ticketService *TicketService) FetchMany(keys []TicketKey) FetchManyResult {
+	return ticketService.FetchManyCtx(context.Background(), keys)
+}
+
+// FetchManyCtx fetches every ticket in keys concurrently, see WithFetchConcurrency. A failed key is
+// reported in the result's Errors and does not stop the others.
+func (ticketService *TicketService) FetchManyCtx(ctx context.Context, keys []TicketKey) FetchManyResult {
+	return FetchTickets(ctx, keys, ticketService.fetchConcurrency, ticketService.FetchWithError)
+}
+
+func (ticketService *TicketService) FetchAll(fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse] {
+	return ticketService.FetchAllCtx(context.Background(), fetchAllRequest)
+}
+
+func (ticketService *TicketService) FetchAllCtx(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse] {
+	return invoke[model.TicketModelsResponse](ctx, ticketService.client, serviceCall{
+		methodName: "TicketService.FetchAll",
+		action:     "fetchAll",
+		kind:       readCall,
+		body:       fetchAllRequest,
+		fields: []any{
+			"client_id", fetchAllRequest.ClientId,
+			"team_id", fetchAllRequest.TeamId,
+			"last_range_key", fetchAllRequest.LastRangeKey,
+		},
+	})
+}
+
+func (ticketService *TicketService) FetchAllWithError(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) (*model.TicketModelsResponse, error) {
+	return result(ticketService.FetchAllCtx(ctx, fetchAllRequest))
+}
+
+func (ticketService *TicketService) FetchByUser(fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse] {
+	return ticketService.FetchByUserCtx(context.Background(), fetchRequest)
+}
+
+func (ticketService *TicketService) FetchByUserCtx(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse] {
+	return invoke[model.TicketModelsResponse](ctx, ticketService.client, serviceCall{
+		methodName: "TicketService.FetchByUser",
+		action:     "fetchByUser",
+		kind:       readCall,
+		body:       fetchRequest,
+		fields:     []any{"user_id", fetchRequest.UserId, "last_range_key", fetchRequest.LastRangeKey},
+	})
+}
+
+func (ticketService *TicketService) FetchByUserWithError(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) (*model.TicketModelsResponse, error) {
+	return result(ticketService.FetchByUserCtx(ctx, fetchRequest))
+}
+
+func (ticketService *TicketService) Update(userId string, ticketModel model.TicketModel) response.Response[bool] {
+	return ticketService.UpdateCtx(context.Background(), userId, ticketModel)
+}
+
+func (ticketService *TicketService) UpdateCtx(ctx context.Context, userId string, ticketModel model.TicketModel) response.Response[bool] {
+	return invoke[bool](ctx, ticketService.client, serviceCall{
+		methodName: "TicketService.Update",
+		action:     "update",
+		kind:       idempotentWriteCall,
+		body: ticket_model_request.TicketModelUpdateRequest{
+			UserId: userId,
+			Ticket: ticketModel,
+		},
+		fields: []any{"user_id", userId, "pk", ticketModel.PartitionKey, "rk", ticketModel.RangeKey},
+	})
+}
+
+func (ticketService *TicketService) UpdateWithError(ctx context.Context, userId string, ticketModel model.TicketModel) error {
+	return ResponseError(ticketService.UpdateCtx(ctx, userId, ticketModel))
+}
+
+func (ticketService *TicketService) Delete(deleteRequest model.DeleteRequest) response.Response[bool] {
+	return ticketService.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (ticketService *TicketService) DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool] {
+	return invoke[bool](ctx, ticketService.client, serviceCall{
+		methodName: "TicketService.Delete",
+		action:     "delete",
+		kind:       idempotentWriteCall,
+		body:       deleteRequest,
+		fields: []any{
+			"pk", deleteRequest.PartitionKey,
+			"rk", deleteRequest.RangeKey,
+			"user_id", deleteRequest.UserId,
+			"is_hard_delete", deleteRequest.IsHardDelete,
+		},
+	})
+}
+
+func (ticketService *TicketService) DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error {
+	return ResponseError(ticketService.DeleteCtx(ctx, deleteRequest))
 }
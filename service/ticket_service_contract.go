@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+)
+
+type TicketServiceContract interface {
+	CreateAutocut(title string, description string, files string, severity int) bool
+	CreateAutocutCtx(ctx context.Context, title string, description string, files string, severity int) bool
+	CreateAutocutWithError(ctx context.Context, title string, description string, files string, severity int) (*model.TicketModel, error)
+	Fetch(partitionKey string, rangeKey string) response.Response[model.TicketModel]
+	FetchCtx(ctx context.Context, partitionKey string, rangeKey string) response.Response[model.TicketModel]
+	FetchWithError(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error)
+	FetchMany(keys []TicketKey) FetchManyResult
+	FetchManyCtx(ctx context.Context, keys []TicketKey) FetchManyResult
+	FetchAll(fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse]
+	FetchAllCtx(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse]
+	FetchAllWithError(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) (*model.TicketModelsResponse, error)
+	FetchByUser(fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse]
+	FetchByUserCtx(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse]
+	FetchByUserWithError(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) (*model.TicketModelsResponse, error)
+	Update(userId string, ticketModel model.TicketModel) response.Response[bool]
+	UpdateCtx(ctx context.Context, userId string, ticketModel model.TicketModel) response.Response[bool]
+	UpdateWithError(ctx context.Context, userId string, ticketModel model.TicketModel) error
+	Delete(deleteRequest model.DeleteRequest) response.Response[bool]
+	DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool]
+	DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error
+	Transition(ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool
+	TransitionCtx(ctx context.Context, ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool
+	TransitionWithError(ctx context.Context, ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) (*model.TicketModel, error)
+}
+
+var _ TicketServiceContract = (*TicketService)(nil)
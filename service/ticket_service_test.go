@@ -0,0 +1,370 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/tickettest"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateAutocutDeduplicatesBeforeRateLimiting(t *testing.T) {
+	type autocut struct {
+		title         string
+		wantThrottled bool
+	}
+	tests := []struct {
+		name         string
+		deduplicate  bool
+		autocuts     []autocut
+		wantTickets  int
+		wantComments int
+	}{
+		{
+			name:        "repeats spend the budget without deduplication",
+			deduplicate: false,
+			autocuts: []autocut{
+				{title: "Disk full"},
+				{title: "Disk full", wantThrottled: true},
+			},
+			wantTickets: 1,
+		},
+		{
+			name:        "repeats are folded in without spending the budget",
+			deduplicate: true,
+			autocuts: []autocut{
+				{title: "Disk full"},
+				{title: "Disk full"},
+				{title: "Disk full"},
+				{title: "Queue stuck", wantThrottled: true},
+			},
+			wantTickets: 1,
+			// The third autocut lands inside the comment interval and waits for the next comment.
+			wantComments: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			server := tickettest.NewServer(backend, "api-key")
+			defer server.Close()
+			opts := []service.Option{
+				service.WithAutocutRateLimit(service.AutocutRateLimit{SeverityPerMinute: map[int]int{3: 1}}),
+			}
+			if test.deduplicate {
+				opts = append(opts, service.WithAutocutDeduplication(time.Hour, tickettest.NewTicketCommentService(backend)))
+			}
+			ticketService := service.ProvideTicketService(server.URL, "api-key", "client", "team", "autocut",
+				ticketmetrics.NoopMetricsManager{}, opts...)
+			for index, autocut := range test.autocuts {
+				_, err := ticketService.CreateAutocutWithError(context.Background(), autocut.title, "description", "", 3)
+				if throttled := errors.Is(err, service.ErrRateLimited); throttled != autocut.wantThrottled {
+					t.Fatalf("autocut %d: error %v, want throttled %t", index, err, autocut.wantThrottled)
+				}
+				if !autocut.wantThrottled && err != nil {
+					t.Fatalf("autocut %d: %v", index, err)
+				}
+			}
+			if tickets := len(backend.Tickets()); tickets != test.wantTickets {
+				t.Errorf("%d tickets created, want %d", tickets, test.wantTickets)
+			}
+			if comments := len(backend.Comments()); comments != test.wantComments {
+				t.Errorf("%d occurrence comments, want %d", comments, test.wantComments)
+			}
+		})
+	}
+}
+
+func TestRunAutocutSpoolReplaysWithinTheRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []service.Option
+		wantTickets int
+	}{
+		{name: "replays straight away without a limit", wantTickets: 1},
+		{
+			name: "waits for the token the failed create spent",
+			opts: []service.Option{
+				service.WithAutocutRateLimit(service.AutocutRateLimit{SeverityPerMinute: map[int]int{3: 1}}),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			server := tickettest.NewServer(backend, "api-key")
+			defer server.Close()
+			spool, err := service.NewAutocutSpool(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewAutocutSpool: %v", err)
+			}
+			opts := append([]service.Option{service.WithAutocutSpool(spool), service.WithRetryPolicy(service.RetryPolicy{})}, test.opts...)
+			ticketService := service.ProvideTicketService(server.URL, "api-key", "client", "team", "autocut",
+				ticketmetrics.NoopMetricsManager{}, opts...)
+			backend.FailNext("TicketService.CreateAutocut", http.StatusServiceUnavailable)
+			if ticketService.CreateAutocut("Disk full", "description", "", 3) {
+				t.Fatal("CreateAutocut succeeded despite the 503")
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			ticketService.RunAutocutSpool(ctx)
+			if tickets := len(backend.Tickets()); tickets != test.wantTickets {
+				t.Errorf("%d tickets replayed, want %d", tickets, test.wantTickets)
+			}
+			if pending, _ := spool.Pending(); pending != 1-test.wantTickets {
+				t.Errorf("Pending = %d, want %d", pending, 1-test.wantTickets)
+			}
+		})
+	}
+}
+
+// errorMetrics records the errors reported to it as "metric status" pairs.
+type errorMetrics struct {
+	ticketmetrics.NoopMetricsManager
+	mu     sync.Mutex
+	errors []string
+}
+
+func (errorMetrics *errorMetrics) Send500Error(callName string, statusCode int, _ string) {
+	errorMetrics.record(callName, statusCode)
+}
+
+func (errorMetrics *errorMetrics) Send400Error(callName string, statusCode int, _ string) {
+	errorMetrics.record(callName, statusCode)
+}
+
+func (errorMetrics *errorMetrics) record(callName string, statusCode int) {
+	errorMetrics.mu.Lock()
+	defer errorMetrics.mu.Unlock()
+	errorMetrics.errors = append(errorMetrics.errors, fmt.Sprintf("%s %d", callName, statusCode))
+}
+
+func (errorMetrics *errorMetrics) reported() []string {
+	errorMetrics.mu.Lock()
+	defer errorMetrics.mu.Unlock()
+	return append([]string(nil), errorMetrics.errors...)
+}
+
+func TestCreateAutocutReportsAnInvalidSeverityOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		send func(ticketService *service.TicketService) error
+	}{
+		{
+			name: "direct",
+			send: func(ticketService *service.TicketService) error {
+				if _, err := ticketService.CreateAutocutWithError(context.Background(), "Disk full", "description", "", 7); !errors.Is(err, model.ErrInvalidValue) {
+					return fmt.Errorf("CreateAutocutWithError = %v, want %v", err, model.ErrInvalidValue)
+				}
+				return nil
+			},
+		},
+		{
+			name: "queued",
+			send: func(ticketService *service.TicketService) error {
+				queue := service.NewAutocutQueue(ticketService, ticketmetrics.NoopMetricsManager{}, 1, 1)
+				if !queue.Enqueue("Disk full", "description", "", 0) {
+					return errors.New("Enqueue into an empty queue failed")
+				}
+				return queue.Close(context.Background())
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			server := tickettest.NewServer(backend, "api-key")
+			defer server.Close()
+			metricsManager := &errorMetrics{}
+			ticketService := service.ProvideTicketService(server.URL, "api-key", "client", "team", "autocut", metricsManager,
+				service.WithAutocutDeduplication(time.Hour, tickettest.NewTicketCommentService(backend)))
+			if err := test.send(&ticketService); err != nil {
+				t.Fatal(err)
+			}
+			if reported := metricsManager.reported(); len(reported) != 1 || reported[0] != "CincinnatiTicketService.create 400" {
+				t.Errorf("reported %q, want one CincinnatiTicketService.create 400", reported)
+			}
+			if tickets := len(backend.Tickets()); tickets != 0 {
+				t.Errorf("%d tickets created for an invalid severity", tickets)
+			}
+		})
+	}
+}
+
+// interferingTicketService runs interfere once, right after the first fetch, the way a write from
+// another process would land between a transition's read and its write.
+type interferingTicketService struct {
+	*tickettest.TicketService
+	interfere func()
+}
+
+func (interfering *interferingTicketService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error) {
+	ticket, err := interfering.TicketService.FetchWithError(ctx, partitionKey, rangeKey)
+	if interfering.interfere != nil {
+		interfering.interfere()
+		interfering.interfere = nil
+	}
+	return ticket, err
+}
+
+func TestTicketTransitionerTransition(t *testing.T) {
+	ticketKey := service.TicketKey{PartitionKey: "client_team", RangeKey: "ticket"}
+	watchers := []string{"u-1", "u-2", "u-3"}
+	tests := []struct {
+		name string
+		to   model.TicketStatus
+		// fail injects a failure into the fake before the transition.
+		fail func(backend *tickettest.Backend)
+		// interfere writes to the ticket between the transition's read and its write.
+		interfere    func(backend *tickettest.Backend)
+		wantErr      []error
+		wantTicket   bool
+		wantStatus   model.TicketStatus
+		wantWatchers int
+	}{
+		{
+			name:         "moves the ticket and its watchers",
+			to:           model.TicketStatusAcknowledged,
+			wantTicket:   true,
+			wantStatus:   model.TicketStatusAcknowledged,
+			wantWatchers: 3,
+		},
+		{
+			name:       "disallowed move",
+			to:         model.TicketStatusResolved,
+			wantErr:    []error{service.ErrInvalidTransition, service.ErrConflict},
+			wantStatus: model.TicketStatusOpen,
+		},
+		{
+			name: "ticket changed underneath",
+			to:   model.TicketStatusAcknowledged,
+			interfere: func(backend *tickettest.Backend) {
+				ticket := backend.Tickets()[0]
+				ticket.Status = string(model.TicketStatusClosed)
+				backend.PutTicket(ticket)
+			},
+			wantErr:    []error{service.ErrTicketChanged, service.ErrConflict},
+			wantStatus: model.TicketStatusClosed,
+		},
+		{
+			name: "update fails",
+			to:   model.TicketStatusAcknowledged,
+			fail: func(backend *tickettest.Backend) {
+				backend.FailNext("TicketService.Update", http.StatusServiceUnavailable)
+			},
+			wantErr:    []error{service.ErrUnavailable},
+			wantStatus: model.TicketStatusOpen,
+		},
+		{
+			name: "one watcher fails",
+			to:   model.TicketStatusAcknowledged,
+			fail: func(backend *tickettest.Backend) {
+				backend.FailNext("TicketWatchService.UpdateWatchEntry", http.StatusInternalServerError)
+			},
+			wantErr:      []error{service.ErrWatchersNotUpdated},
+			wantTicket:   true,
+			wantStatus:   model.TicketStatusAcknowledged,
+			wantWatchers: 2,
+		},
+		{
+			name: "watchers cannot be listed",
+			to:   model.TicketStatusAcknowledged,
+			fail: func(backend *tickettest.Backend) {
+				backend.FailNext("TicketWatchService.GetTicketWatchers", http.StatusInternalServerError)
+			},
+			wantErr:    []error{service.ErrWatchersNotUpdated},
+			wantTicket: true,
+			wantStatus: model.TicketStatusAcknowledged,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			backend.PutTicket(model.TicketModel{
+				PartitionKey: ticketKey.PartitionKey,
+				RangeKey:     ticketKey.RangeKey,
+				Title:        "Disk full",
+				Status:       string(model.TicketStatusOpen),
+				Modified:     "2024-01-02T03:04:05Z",
+			})
+			for _, userId := range watchers {
+				backend.PutWatch(model.TicketWatchModel{
+					PartitionKey: userId,
+					RangeKey:     ticketKey.PartitionKey + "_" + ticketKey.RangeKey,
+					TicketStatus: string(model.TicketStatusOpen),
+				})
+			}
+			if test.fail != nil {
+				test.fail(backend)
+			}
+			ticketService := &interferingTicketService{TicketService: tickettest.NewTicketService(backend, "client", "team", "autocut")}
+			if test.interfere != nil {
+				ticketService.interfere = func() { test.interfere(backend) }
+			}
+			ticket, err := service.NewTicketTransitioner(nil, 2, nil).Transition(context.Background(),
+				ticketService, tickettest.NewTicketWatchService(backend), ticketKey, test.to, "u-1", "on it")
+			for _, target := range test.wantErr {
+				if !errors.Is(err, target) {
+					t.Errorf("Transition error %v does not match %v", err, target)
+				}
+			}
+			if len(test.wantErr) == 0 && err != nil {
+				t.Errorf("Transition: %v", err)
+			}
+			if (ticket != nil) != test.wantTicket {
+				t.Errorf("Transition returned ticket %+v, want one %t", ticket, test.wantTicket)
+			}
+			if status := backend.Tickets()[0].TicketStatus(); status != test.wantStatus {
+				t.Errorf("stored status %q, want %q", status, test.wantStatus)
+			}
+			moved := 0
+			for _, watch := range backend.Watches() {
+				if watch.WatchedTicketStatus() == test.to {
+					moved++
+				}
+			}
+			if moved != test.wantWatchers {
+				t.Errorf("%d watch entries show %q, want %d", moved, test.to, test.wantWatchers)
+			}
+		})
+	}
+}
+
+func TestTicketTransitionerSerializesTransitionsOfATicket(t *testing.T) {
+	ticketKey := service.TicketKey{PartitionKey: "client_team", RangeKey: "ticket"}
+	backend := tickettest.NewBackend()
+	backend.PutTicket(model.TicketModel{
+		PartitionKey: ticketKey.PartitionKey,
+		RangeKey:     ticketKey.RangeKey,
+		Status:       string(model.TicketStatusOpen),
+	})
+	ticketService := tickettest.NewTicketService(backend, "client", "team", "autocut")
+	// Both moves are allowed from OPEN but only one of them from the other, so exactly one succeeds
+	// once they no longer overwrite each other.
+	targets := []model.TicketStatus{model.TicketStatusInProgress, model.TicketStatusClosed}
+	errs := make(chan error, len(targets))
+	for _, target := range targets {
+		go func(target model.TicketStatus) {
+			_, err := ticketService.TransitionWithError(context.Background(), ticketKey, target, "u-1", "")
+			errs <- err
+		}(target)
+	}
+	failed := 0
+	for range targets {
+		if err := <-errs; err != nil {
+			if !errors.Is(err, service.ErrInvalidTransition) {
+				t.Fatalf("Transition: %v", err)
+			}
+			failed++
+		}
+	}
+	if entries := backend.Tickets()[0].StatusHistoryEntries(); failed != 1 || len(entries) != 1 {
+		t.Errorf("%d transitions failed leaving %d history entries, want 1 and 1", failed, len(entries))
+	}
+}
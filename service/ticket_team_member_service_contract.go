@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_member_model_request"
+)
+
+type TicketTeamMemberServiceContract interface {
+	Create(createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) response.Response[model2.TicketTeamMemberModel]
+	CreateCtx(ctx context.Context, createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) response.Response[model2.TicketTeamMemberModel]
+	CreateWithError(ctx context.Context, createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) (*model2.TicketTeamMemberModel, error)
+	Update(userId string, memberModel model2.TicketTeamMemberModel) response.Response[bool]
+	UpdateCtx(ctx context.Context, userId string, memberModel model2.TicketTeamMemberModel) response.Response[bool]
+	UpdateWithError(ctx context.Context, userId string, memberModel model2.TicketTeamMemberModel) error
+	Delete(deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error
+	FetchAll(fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) response.Response[model2.TicketTeamMemberModelsResponse]
+	FetchAllCtx(ctx context.Context, fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) response.Response[model2.TicketTeamMemberModelsResponse]
+	FetchAllWithError(ctx context.Context, fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) (*model2.TicketTeamMemberModelsResponse, error)
+	FetchByUser(fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) response.Response[model2.TicketTeamMemberModelsResponse]
+	FetchByUserCtx(ctx context.Context, fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) response.Response[model2.TicketTeamMemberModelsResponse]
+	FetchByUserWithError(ctx context.Context, fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) (*model2.TicketTeamMemberModelsResponse, error)
+	Fetch(email string, partitionKey string, rangeKey string) response.Response[model2.TicketTeamMemberModel]
+	FetchCtx(ctx context.Context, email string, partitionKey string, rangeKey string) response.Response[model2.TicketTeamMemberModel]
+	FetchWithError(ctx context.Context, email string, partitionKey string, rangeKey string) (*model2.TicketTeamMemberModel, error)
+}
+
+var _ TicketTeamMemberServiceContract = (*TicketTeamMemberService)(nil)
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_model_request"
+)
+
+type TicketTeamServiceContract interface {
+	Create(createRequest ticket_team_model_request.TicketTeamModelCreateRequest) response.Response[model2.TicketTeamModel]
+	CreateCtx(ctx context.Context, createRequest ticket_team_model_request.TicketTeamModelCreateRequest) response.Response[model2.TicketTeamModel]
+	CreateWithError(ctx context.Context, createRequest ticket_team_model_request.TicketTeamModelCreateRequest) (*model2.TicketTeamModel, error)
+	Update(userId string, teamModel model2.TicketTeamModel) response.Response[bool]
+	UpdateCtx(ctx context.Context, userId string, teamModel model2.TicketTeamModel) response.Response[bool]
+	UpdateWithError(ctx context.Context, userId string, teamModel model2.TicketTeamModel) error
+	Fetch(partitionKey string, rangeKey string, userId string) response.Response[model2.TicketTeamModel]
+	FetchCtx(ctx context.Context, partitionKey string, rangeKey string, userId string) response.Response[model2.TicketTeamModel]
+	FetchWithError(ctx context.Context, partitionKey string, rangeKey string, userId string) (*model2.TicketTeamModel, error)
+	FetchAll(clientId string, lastRangeKey *string) response.Response[model2.TicketTeamModelsResponse]
+	FetchAllCtx(ctx context.Context, clientId string, lastRangeKey *string) response.Response[model2.TicketTeamModelsResponse]
+	FetchAllWithError(ctx context.Context, clientId string, lastRangeKey *string) (*model2.TicketTeamModelsResponse, error)
+	Delete(deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool]
+	DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error
+}
+
+var _ TicketTeamServiceContract = (*TicketTeamService)(nil)
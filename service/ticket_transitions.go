@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTransition is matched by errors.Is when the state machine does not allow a ticket to move
+// from its current status to the one asked for.
+var ErrInvalidTransition = errors.New("status transition not allowed")
+
+// ErrTicketChanged is matched by errors.Is when a ticket changed between being read for a transition
+// and being written back. Nothing is written then; the transition can be tried again.
+var ErrTicketChanged = errors.New("ticket changed during transition")
+
+// ErrWatchersNotUpdated is matched by errors.Is when a ticket was transitioned but some watch entries
+// could not be refreshed. The transitioned ticket is returned along with it.
+var ErrWatchersNotUpdated = errors.New("watchers not updated")
+
+// TicketTransitions maps each status to the statuses a ticket may move to from it.
+type TicketTransitions map[model.TicketStatus][]model.TicketStatus
+
+// DefaultTicketTransitions walks tickets through OPEN, ACKNOWLEDGED, IN_PROGRESS, RESOLVED and CLOSED.
+// Open and acknowledged tickets may be closed outright, and resolved or closed tickets reopened.
+func DefaultTicketTransitions() TicketTransitions {
+	return TicketTransitions{
+		model.TicketStatusOpen:         {model.TicketStatusAcknowledged, model.TicketStatusInProgress, model.TicketStatusClosed},
+		model.TicketStatusAcknowledged: {model.TicketStatusInProgress, model.TicketStatusClosed},
+		model.TicketStatusInProgress:   {model.TicketStatusResolved, model.TicketStatusAcknowledged},
+		model.TicketStatusResolved:     {model.TicketStatusClosed, model.TicketStatusOpen},
+		model.TicketStatusClosed:       {model.TicketStatusOpen},
+	}
+}
+
+func (transitions TicketTransitions) Allows(from model.TicketStatus, to model.TicketStatus) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply moves ticket to toStatus and appends the move to its StatusHistory. It fails with an error
+// matching model.ErrInvalidValue for an unknown status or a missing actor, and ErrInvalidTransition
+// when the move is not allowed; ticket is left unchanged then.
+func (transitions TicketTransitions) Apply(
+	ticket *model.TicketModel,
+	toStatus model.TicketStatus,
+	actor string,
+	reason string,
+	at time.Time,
+) error {
+	if err := validateTransition(toStatus, actor); err != nil {
+		return err
+	}
+	from := ticket.TicketStatus()
+	if !transitions.Allows(from, toStatus) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, toStatus)
+	}
+	transitioned := *ticket
+	err := transitioned.AppendStatusHistory(model.StatusHistoryEntry{
+		From:   from,
+		To:     toStatus,
+		Actor:  actor,
+		Reason: reason,
+		At:     at.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+	transitioned.Status = string(toStatus)
+	*ticket = transitioned
+	return nil
+}
+
+func validateTransition(toStatus model.TicketStatus, actor string) error {
+	var statusError, actorError error
+	if toStatus == "" {
+		statusError = fmt.Errorf("%w: status is required", model.ErrInvalidValue)
+	}
+	if strings.TrimSpace(actor) == "" {
+		actorError = fmt.Errorf("%w: actor is required", model.ErrInvalidValue)
+	}
+	return errors.Join(statusError, toStatus.Validate(), actorError)
+}
+
+// transitionError shapes a failed Apply as the *RequestError Transition returns.
+func transitionError(err error) error {
+	if errors.Is(err, ErrInvalidTransition) || errors.Is(err, ErrTicketChanged) {
+		return &RequestError{Kind: ErrorKindValidation, StatusCode: http.StatusConflict, Message: err.Error(), Err: err}
+	}
+	return invalidRequestError(err)
+}
+
+// TicketTransitioner runs status transitions through the Fetch and Update calls of a
+// TicketServiceContract and the UpdateWatchEntry calls of a TicketWatchServiceContract. TicketService
+// transitions tickets with one, and so do the tickettest fakes, so both fail the same way.
+type TicketTransitioner struct {
+	transitions TicketTransitions
+	// concurrency bounds the watch entry updates in flight.
+	concurrency int
+	logger      *slog.Logger
+	now         func() time.Time
+	locks       *ticketLocks
+}
+
+// NewTicketTransitioner enforces transitions, DefaultTicketTransitions when nil, and updates up to
+// concurrency watch entries at once, DefaultFetchConcurrency when not positive.
+func NewTicketTransitioner(transitions TicketTransitions, concurrency int, logger *slog.Logger) *TicketTransitioner {
+	if transitions == nil {
+		transitions = DefaultTicketTransitions()
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+	return &TicketTransitioner{
+		transitions: transitions,
+		concurrency: concurrency,
+		logger:      loggerOrDiscard(logger),
+		now:         time.Now,
+		locks:       &ticketLocks{held: map[TicketKey]*ticketLock{}},
+	}
+}
+
+// Transition moves the ticket to toStatus if the state machine allows it, records the move in its
+// StatusHistory and refreshes the status on every watch entry for it; watchService may be nil to skip
+// that. A disallowed move fails with a 409 matching ErrInvalidTransition and ErrConflict.
+//
+// The service has no conditional update, so the ticket is read again right before it is written and
+// the transition fails with a 409 matching ErrTicketChanged if its status or modified time moved.
+// Transitions of one ticket through the same transitioner also run one at a time. A write from
+// elsewhere landing between that check and the update is still overwritten.
+//
+// When only watch entries fail, the transitioned ticket is returned with an error matching
+// ErrWatchersNotUpdated; the entries that could be updated are.
+func (transitioner *TicketTransitioner) Transition(
+	ctx context.Context,
+	ticketService TicketServiceContract,
+	watchService TicketWatchServiceContract,
+	ticketKey TicketKey,
+	toStatus model.TicketStatus,
+	actor string,
+	reason string,
+) (*model.TicketModel, error) {
+	logger := transitioner.logger.With("method", "TicketService.Transition",
+		"pk", ticketKey.PartitionKey, "rk", ticketKey.RangeKey, "to", toStatus.String(), "actor", actor)
+	if err := validateTransition(toStatus, actor); err != nil {
+		logger.WarnContext(ctx, "INVALID_REQUEST", "error", err)
+		return nil, transitionError(err)
+	}
+	unlock, err := transitioner.locks.lock(ctx, ticketKey)
+	if err != nil {
+		logger.WarnContext(ctx, "CONTEXT_ERROR", "error", err)
+		return nil, &RequestError{Kind: ErrorKindTransport, StatusCode: contextStatusCode(err), Message: err.Error(), Err: err}
+	}
+	defer unlock()
+	ticket, err := ticketService.FetchWithError(ctx, ticketKey.PartitionKey, ticketKey.RangeKey)
+	if err != nil {
+		return nil, err
+	}
+	read := *ticket
+	from := ticket.TicketStatus()
+	if err := transitioner.transitions.Apply(ticket, toStatus, actor, reason, transitioner.now()); err != nil {
+		logger.WarnContext(ctx, "INVALID_TRANSITION", "from", from.String(), "error", err)
+		return nil, transitionError(err)
+	}
+	current, err := ticketService.FetchWithError(ctx, ticketKey.PartitionKey, ticketKey.RangeKey)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != read.Status || current.Modified != read.Modified {
+		err := fmt.Errorf("%w: status %s, modified %s", ErrTicketChanged, current.Status, current.Modified)
+		logger.WarnContext(ctx, "CONFLICT", "from", from.String(), "error", err)
+		return nil, transitionError(err)
+	}
+	if err := ticketService.UpdateWithError(ctx, actor, *ticket); err != nil {
+		return nil, err
+	}
+	logger.InfoContext(ctx, "TRANSITIONED", "from", from.String(), "reason", reason)
+	if err := transitioner.updateWatchers(ctx, watchService, *ticket, actor); err != nil {
+		logger.WarnContext(ctx, "WATCHERS_ERROR", "error", err)
+		return ticket, err
+	}
+	return ticket, nil
+}
+
+// updateWatchers refreshes the status on every watch entry for ticket, up to concurrency at a time,
+// carrying on past failures and reporting them together.
+func (transitioner *TicketTransitioner) updateWatchers(
+	ctx context.Context,
+	watchService TicketWatchServiceContract,
+	ticket model.TicketModel,
+	actor string,
+) error {
+	if watchService == nil {
+		return nil
+	}
+	pager := NewTicketWatchersPager(watchService, ticket_watch_request.TicketWatchersListRequest{
+		TicketPartitionKey: ticket.PartitionKey,
+		TicketRangeKey:     ticket.RangeKey,
+		UserId:             actor,
+	})
+	var watches []*model.TicketWatchModel
+	var failures []error
+	for !pager.Done() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			failures = append(failures, err)
+			break
+		}
+		watches = append(watches, page...)
+	}
+	lastUpdated := transitioner.now().UTC().Format(time.RFC3339Nano)
+	var mu sync.Mutex
+	var waitGroup sync.WaitGroup
+	slots := make(chan struct{}, transitioner.concurrency)
+	for _, watch := range watches {
+		slots <- struct{}{}
+		waitGroup.Add(1)
+		go func(userId string) {
+			defer func() {
+				<-slots
+				waitGroup.Done()
+			}()
+			err := watchService.UpdateWatchEntryWithError(ctx, ticket_watch_request.TicketWatchUpdateRequest{
+				UserId:             userId,
+				TicketPartitionKey: ticket.PartitionKey,
+				TicketRangeKey:     ticket.RangeKey,
+				TicketTitle:        ticket.Title,
+				TicketStatus:       ticket.Status,
+				LastUpdated:        lastUpdated,
+			})
+			if err != nil {
+				mu.Lock()
+				failures = append(failures, err)
+				mu.Unlock()
+			}
+		}(watch.PartitionKey)
+	}
+	waitGroup.Wait()
+	if len(failures) > 0 {
+		return fmt.Errorf("%w: %w", ErrWatchersNotUpdated, errors.Join(failures...))
+	}
+	return nil
+}
+
+// ticketLocks lets one transition per ticket run at a time. Waiting gives up when ctx is done.
+type ticketLocks struct {
+	mu   sync.Mutex
+	held map[TicketKey]*ticketLock
+}
+
+type ticketLock struct {
+	token chan struct{}
+	// users counts the holder and waiters, so the entry is dropped once nobody needs it.
+	users int
+}
+
+func (locks *ticketLocks) lock(ctx context.Context, key TicketKey) (func(), error) {
+	locks.mu.Lock()
+	entry, ok := locks.held[key]
+	if !ok {
+		entry = &ticketLock{token: make(chan struct{}, 1)}
+		locks.held[key] = entry
+	}
+	entry.users++
+	locks.mu.Unlock()
+	select {
+	case entry.token <- struct{}{}:
+		return func() {
+			<-entry.token
+			locks.release(key, entry)
+		}, nil
+	case <-ctx.Done():
+		locks.release(key, entry)
+		return nil, ctx.Err()
+	}
+}
+
+func (locks *ticketLocks) release(key TicketKey, entry *ticketLock) {
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+	entry.users--
+	if entry.users == 0 {
+		delete(locks.held, key)
+	}
+}
+
+func (ticketService *TicketService) Transition(ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	return ticketService.TransitionCtx(context.Background(), ticketKey, toStatus, actor, reason)
+}
+
+func (ticketService *TicketService) TransitionCtx(ctx context.Context, ticketKey TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	_, err := ticketService.TransitionWithError(ctx, ticketKey, toStatus, actor, reason)
+	return err == nil
+}
+
+// TransitionWithError runs the transition through this service and, with WithTicketWatchService, the
+// watch service; see TicketTransitioner.Transition and WithTicketTransitions.
+func (ticketService *TicketService) TransitionWithError(
+	ctx context.Context,
+	ticketKey TicketKey,
+	toStatus model.TicketStatus,
+	actor string,
+	reason string,
+) (*model.TicketModel, error) {
+	return ticketService.transitioner.Transition(ctx, ticketService, ticketService.watchService, ticketKey, toStatus, actor, reason)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTicketTransitionsApply(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		from    model.TicketStatus
+		to      model.TicketStatus
+		actor   string
+		wantErr error
+	}{
+		{name: "acknowledge", from: model.TicketStatusOpen, to: model.TicketStatusAcknowledged, actor: "u-1"},
+		{name: "close outright", from: model.TicketStatusOpen, to: model.TicketStatusClosed, actor: "u-1"},
+		{name: "reopen", from: model.TicketStatusClosed, to: model.TicketStatusOpen, actor: "u-1"},
+		{name: "skip ahead", from: model.TicketStatusOpen, to: model.TicketStatusResolved, actor: "u-1", wantErr: ErrInvalidTransition},
+		{name: "stay put", from: model.TicketStatusOpen, to: model.TicketStatusOpen, actor: "u-1", wantErr: ErrInvalidTransition},
+		{name: "from no status", to: model.TicketStatusOpen, actor: "u-1", wantErr: ErrInvalidTransition},
+		{name: "unknown status", from: model.TicketStatusOpen, to: "ARCHIVED", actor: "u-1", wantErr: model.ErrInvalidValue},
+		{name: "empty status", from: model.TicketStatusOpen, actor: "u-1", wantErr: model.ErrInvalidValue},
+		{name: "no actor", from: model.TicketStatusOpen, to: model.TicketStatusClosed, actor: " ", wantErr: model.ErrInvalidValue},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ticket := model.TicketModel{Status: string(test.from), StatusHistory: "created by hand"}
+			before := ticket
+			err := DefaultTicketTransitions().Apply(&ticket, test.to, test.actor, "because", at)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Apply = %v, want %v", err, test.wantErr)
+			}
+			if err != nil {
+				if ticket != before {
+					t.Errorf("failed Apply changed the ticket to %+v", ticket)
+				}
+				return
+			}
+			want := model.StatusHistoryEntry{From: test.from, To: test.to, Actor: test.actor, Reason: "because", At: "2024-01-02T03:04:05Z"}
+			entries := ticket.StatusHistoryEntries()
+			if ticket.TicketStatus() != test.to || len(entries) != 1 || entries[0] != want {
+				t.Errorf("Apply left status %q and history %+v, want %q and %+v", ticket.Status, entries, test.to, want)
+			}
+		})
+	}
+}
+
+func TestTransitionError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantMatches    []error
+	}{
+		{name: "invalid transition", err: ErrInvalidTransition, wantStatusCode: http.StatusConflict, wantMatches: []error{ErrInvalidTransition, ErrConflict}},
+		{name: "ticket changed", err: ErrTicketChanged, wantStatusCode: http.StatusConflict, wantMatches: []error{ErrTicketChanged, ErrConflict}},
+		{name: "invalid value", err: model.ErrInvalidValue, wantStatusCode: http.StatusBadRequest, wantMatches: []error{model.ErrInvalidValue, ErrInvalidRequest}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := transitionError(test.err)
+			var requestError *RequestError
+			if !errors.As(err, &requestError) || requestError.StatusCode != test.wantStatusCode {
+				t.Fatalf("transitionError = %v, want a %d *RequestError", err, test.wantStatusCode)
+			}
+			for _, target := range test.wantMatches {
+				if !errors.Is(err, target) {
+					t.Errorf("%v does not match %v", err, target)
+				}
+			}
+		})
+	}
+}
+
+func TestTicketLocks(t *testing.T) {
+	locks := &ticketLocks{held: map[TicketKey]*ticketLock{}}
+	first, second := TicketKey{RangeKey: "a"}, TicketKey{RangeKey: "b"}
+	unlock, err := locks.lock(context.Background(), first)
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	// Another ticket is not held up.
+	unlockSecond, err := locks.lock(context.Background(), second)
+	if err != nil {
+		t.Fatalf("lock of another ticket: %v", err)
+	}
+	unlockSecond()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.lock(ctx, first); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("lock of a held ticket = %v, want %v", err, context.DeadlineExceeded)
+	}
+	acquired := make(chan func())
+	go func() {
+		unlock, _ := locks.lock(context.Background(), first)
+		acquired <- unlock
+	}()
+	unlock()
+	(<-acquired)()
+	if len(locks.held) != 0 {
+		t.Errorf("%d locks still tracked after every holder let go", len(locks.held))
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+)
+
+type TicketWatchServiceContract interface {
+	AddWatcher(addRequest ticket_watch_request.TicketWatchAddRequest) response.Response[model2.TicketWatchModel]
+	AddWatcherCtx(ctx context.Context, addRequest ticket_watch_request.TicketWatchAddRequest) response.Response[model2.TicketWatchModel]
+	AddWatcherWithError(ctx context.Context, addRequest ticket_watch_request.TicketWatchAddRequest) (*model2.TicketWatchModel, error)
+	RemoveWatcher(removeRequest ticket_watch_request.TicketWatchRemoveRequest) response.Response[bool]
+	RemoveWatcherCtx(ctx context.Context, removeRequest ticket_watch_request.TicketWatchRemoveRequest) response.Response[bool]
+	RemoveWatcherWithError(ctx context.Context, removeRequest ticket_watch_request.TicketWatchRemoveRequest) error
+	GetUserWatchList(fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetUserWatchListCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetUserWatchListWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) (*model2.TicketWatchModelsResponse, error)
+	GetUserUnreadList(fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetUserUnreadListCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetUserUnreadListWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) (*model2.TicketWatchModelsResponse, error)
+	GetTicketWatchers(fetchRequest ticket_watch_request.TicketWatchersListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetTicketWatchersCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchersListRequest) response.Response[model2.TicketWatchModelsResponse]
+	GetTicketWatchersWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchersListRequest) (*model2.TicketWatchModelsResponse, error)
+	MarkAsRead(markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) response.Response[bool]
+	MarkAsReadCtx(ctx context.Context, markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) response.Response[bool]
+	MarkAsReadWithError(ctx context.Context, markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) error
+	UpdateWatchEntry(updateRequest ticket_watch_request.TicketWatchUpdateRequest) response.Response[bool]
+	UpdateWatchEntryCtx(ctx context.Context, updateRequest ticket_watch_request.TicketWatchUpdateRequest) response.Response[bool]
+	UpdateWatchEntryWithError(ctx context.Context, updateRequest ticket_watch_request.TicketWatchUpdateRequest) error
+}
+
+var _ TicketWatchServiceContract = (*TicketWatchService)(nil)
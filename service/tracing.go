@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strings"
+)
+
+const tracerName = "github.com/nicholaspark09/cincinnatiticketlibrary/service"
+
+// spanKeyFields are the call fields recorded on spans. User ids and free text are left out so traces
+// need no redaction.
+var spanKeyFields = map[string]bool{
+	"pk":         true,
+	"rk":         true,
+	"ticket_pk":  true,
+	"ticket_rk":  true,
+	"ticket_key": true,
+	"comment_pk": true,
+	"comment_rk": true,
+	"client_id":  true,
+	"team_id":    true,
+	"severity":   true,
+}
+
+// startSpan starts the client span for one service call. The span covers every attempt; retries are
+// recorded as events on it.
+func startSpan(ctx context.Context, client serviceClient, call serviceCall) (context.Context, trace.Span) {
+	serviceName, _, _ := strings.Cut(call.methodName, ".")
+	attributes := []attribute.KeyValue{
+		attribute.String("cincinnati.service", serviceName),
+		attribute.String("cincinnati.controller", client.controllerName),
+		attribute.String("cincinnati.action", call.action),
+		attribute.String("http.request.method", call.httpMethod),
+	}
+	for i := 0; i+1 < len(call.fields); i += 2 {
+		key, _ := call.fields[i].(string)
+		if !spanKeyFields[key] {
+			continue
+		}
+		switch value := call.fields[i+1].(type) {
+		case string:
+			attributes = append(attributes, attribute.String("cincinnati."+key, value))
+		case int:
+			attributes = append(attributes, attribute.Int("cincinnati."+key, value))
+		}
+	}
+	return client.tracer.Start(ctx, call.methodName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+}
+
+// endSpan records the outcome of the call on span and ends it.
+func endSpan(span trace.Span, statusCode int, err error) {
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// injectTraceContext adds the traceparent and tracestate headers for the span active in ctx.
+func injectTraceContext(ctx context.Context, client serviceClient, header http.Header) {
+	client.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func tracerOrGlobal(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// TraceIdFromContext returns the id of the trace active in ctx, or "" when there is none.
+func TraceIdFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// LinkTrace stamps an autocut with the trace active in ctx so on-call can jump from the ticket to the
+// failing trace. The id is sent as trace_id and also noted at the end of the description, which every
+// ticket view shows.
+func LinkTrace(ctx context.Context, createRequest *ticket_model_request.TicketModelCreateRequest) {
+	traceId := TraceIdFromContext(ctx)
+	if traceId == "" {
+		return
+	}
+	createRequest.TraceId = traceId
+	createRequest.Description += traceNote(traceId)
+}
+
+func traceNote(traceId string) string {
+	if traceId == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\nTrace ID: %s", traceId)
+}
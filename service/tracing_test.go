@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"context"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/tickettest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTracedTicketService serves backend and records every span the returned service ends.
+func newTracedTicketService(t *testing.T, backend *tickettest.Backend) (service.TicketService, *sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	t.Helper()
+	server := tickettest.NewServer(backend, "api-key")
+	t.Cleanup(server.Close)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ticketService := service.ProvideTicketService(server.URL, "api-key", "client", "team", "autocut", ticketmetrics.NoopMetricsManager{},
+		service.WithTracerProvider(provider),
+		service.WithRetryPolicy(service.RetryPolicy{
+			MaxAttempts:          2,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}))
+	return ticketService, provider, recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, keyValue := range span.Attributes() {
+		if keyValue.Key == key {
+			return keyValue.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestEveryCallRecordsOneSpan(t *testing.T) {
+	tests := []struct {
+		name           string
+		failure        int
+		wantStatusCode int64
+		wantStatus     codes.Code
+		wantEvents     []string
+	}{
+		{name: "success", wantStatusCode: 200, wantStatus: codes.Unset},
+		{name: "retried", failure: http.StatusServiceUnavailable, wantStatusCode: 200, wantStatus: codes.Unset, wantEvents: []string{"retry"}},
+		{name: "failed", failure: http.StatusNotFound, wantStatusCode: 404, wantStatus: codes.Error, wantEvents: []string{"exception"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := tickettest.NewBackend()
+			ticketService, _, recorder := newTracedTicketService(t, backend)
+			created, err := ticketService.CreateAutocutWithError(context.Background(), "Disk full", "", "", 2)
+			if err != nil {
+				t.Fatalf("CreateAutocut: %v", err)
+			}
+			if test.failure != 0 {
+				backend.FailNext("TicketService.Fetch", test.failure)
+			}
+			_, _ = ticketService.FetchWithError(context.Background(), created.PartitionKey, created.RangeKey)
+
+			spans := recorder.Ended()
+			if len(spans) != 2 {
+				t.Fatalf("%d spans ended, want one for the create and one for the fetch", len(spans))
+			}
+			span := spans[1]
+			if span.Name() != "TicketService.Fetch" || span.SpanKind() != trace.SpanKindClient {
+				t.Errorf("span %s of kind %s, want a client span TicketService.Fetch", span.Name(), span.SpanKind())
+			}
+			if statusCode, _ := spanAttribute(span, "http.response.status_code"); statusCode.AsInt64() != test.wantStatusCode {
+				t.Errorf("http.response.status_code = %d, want %d", statusCode.AsInt64(), test.wantStatusCode)
+			}
+			if pk, _ := spanAttribute(span, "cincinnati.pk"); pk.AsString() != created.PartitionKey {
+				t.Errorf("cincinnati.pk = %q, want %q", pk.AsString(), created.PartitionKey)
+			}
+			if span.Status().Code != test.wantStatus {
+				t.Errorf("status %s, want %s", span.Status().Code, test.wantStatus)
+			}
+			var events []string
+			for _, event := range span.Events() {
+				events = append(events, event.Name)
+			}
+			if strings.Join(events, ",") != strings.Join(test.wantEvents, ",") {
+				t.Errorf("events %v, want %v", events, test.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCreateAutocutCarriesTheTraceId(t *testing.T) {
+	backend := tickettest.NewBackend()
+	ticketService, provider, recorder := newTracedTicketService(t, backend)
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "job")
+	if !ticketService.CreateAutocutCtx(ctx, "Disk full", "/var is at 100%", "", 2) {
+		t.Fatal("CreateAutocut failed")
+	}
+	parent.End()
+	traceId := parent.SpanContext().TraceID().String()
+	tickets := backend.Tickets()
+	if len(tickets) != 1 || tickets[0].TraceId != traceId {
+		t.Fatalf("stored %+v, want one ticket with trace id %s", tickets, traceId)
+	}
+	if want := "/var is at 100%\n\nTrace ID: " + traceId; tickets[0].Description != want {
+		t.Errorf("description %q, want %q", tickets[0].Description, want)
+	}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != traceId {
+			t.Errorf("span %s is in trace %s, want %s", span.Name(), span.SpanContext().TraceID(), traceId)
+		}
+	}
+}
+
+func TestLinkTrace(t *testing.T) {
+	createRequest := ticket_model_request.TicketModelCreateRequest{Description: "Disk full"}
+	service.LinkTrace(context.Background(), &createRequest)
+	if createRequest.TraceId != "" || createRequest.Description != "Disk full" {
+		t.Errorf("without a trace LinkTrace changed the request to %+v", createRequest)
+	}
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "job")
+	defer span.End()
+	traceId := span.SpanContext().TraceID().String()
+	service.LinkTrace(ctx, &createRequest)
+	if createRequest.TraceId != traceId || createRequest.Description != "Disk full\n\nTrace ID: "+traceId {
+		t.Errorf("LinkTrace = %+v, want trace id %s appended", createRequest, traceId)
+	}
+	if service.TraceIdFromContext(ctx) != traceId {
+		t.Errorf("TraceIdFromContext = %q, want %q", service.TraceIdFromContext(ctx), traceId)
+	}
+}
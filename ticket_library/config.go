@@ -0,0 +1,203 @@
+package ticket_library
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigError reports one invalid or missing setting. NewTicketLibrary joins one per problem, so
+// errors.As finds the first and the message lists them all.
+type ConfigError struct {
+	Field  string
+	Reason string
+}
+
+func (configError *ConfigError) Error() string {
+	return fmt.Sprintf("ticket_library: %s %s", configError.Field, configError.Reason)
+}
+
+// setting names a value for validation the way the caller supplied it: an option, an environment
+// variable or a config file key.
+type setting struct {
+	field    string
+	value    string
+	required bool
+	endpoint bool
+}
+
+func validateSettings(settings []setting) error {
+	var problems []error
+	for _, current := range settings {
+		switch {
+		case strings.TrimSpace(current.value) == "":
+			if current.required {
+				problems = append(problems, &ConfigError{Field: current.field, Reason: "is required"})
+			}
+		case current.endpoint:
+			if err := validateEndpoint(current.field, current.value); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+	return errors.Join(problems...)
+}
+
+func (options libraryOptions) validate() error {
+	return validateSettings([]setting{
+		{field: "client id", value: options.clientId, required: true},
+		{field: "endpoint", value: options.endpoint, required: true, endpoint: true},
+		{field: "api key", value: options.apiKey, required: true},
+		{field: "autocut key", value: options.autoCutKey, required: true},
+	})
+}
+
+func validateEndpoint(field string, endpoint string) error {
+	parsedUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return &ConfigError{Field: field, Reason: fmt.Sprintf("is not a valid URL: %v", err)}
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return &ConfigError{Field: field, Reason: "must be an http or https URL"}
+	}
+	if parsedUrl.Host == "" {
+		return &ConfigError{Field: field, Reason: "has no host"}
+	}
+	return nil
+}
+
+// Environment variables read by FromEnv.
+const (
+	EnvClientId   = "CINCINNATI_TICKET_CLIENT_ID"
+	EnvTeamId     = "CINCINNATI_TICKET_TEAM_ID"
+	EnvEndpoint   = "CINCINNATI_TICKET_ENDPOINT"
+	EnvApiKey     = "CINCINNATI_TICKET_API_KEY"
+	EnvAutoCutKey = "CINCINNATI_TICKET_AUTOCUT_KEY"
+)
+
+// Config holds what a TicketLibrary needs to reach CincinnatiTicketService. Pass it to
+// NewTicketLibrary with WithConfig. String, LogValue, MarshalJSON and MarshalYAML redact the api and
+// autocut keys, so a Config is safe to log or dump.
+type Config struct {
+	ClientId   string `json:"client_id" yaml:"client_id"`
+	TeamId     string `json:"team_id" yaml:"team_id"`
+	Endpoint   string `json:"endpoint" yaml:"endpoint"`
+	ApiKey     string `json:"api_key" yaml:"api_key"`
+	AutoCutKey string `json:"autocut_key" yaml:"autocut_key"`
+}
+
+// FromEnv reads the CINCINNATI_TICKET_* variables. The team id is optional; a missing or invalid
+// variable is reported by name.
+func FromEnv() (Config, error) {
+	config := Config{
+		ClientId:   os.Getenv(EnvClientId),
+		TeamId:     os.Getenv(EnvTeamId),
+		Endpoint:   os.Getenv(EnvEndpoint),
+		ApiKey:     os.Getenv(EnvApiKey),
+		AutoCutKey: os.Getenv(EnvAutoCutKey),
+	}
+	err := config.validate(EnvClientId, EnvEndpoint, EnvApiKey, EnvAutoCutKey)
+	return config, err
+}
+
+// FromFile reads a JSON file, when path ends in ".json", or a YAML file, using the keys client_id,
+// team_id, endpoint, api_key and autocut_key. Unknown keys are rejected so typos do not go unnoticed.
+func FromFile(path string) (Config, error) {
+	var config Config
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("ticket_library: reading config: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(contents))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(contents))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("ticket_library: parsing config %s: %w", path, err)
+	}
+	return config, config.validate("client_id", "endpoint", "api_key", "autocut_key")
+}
+
+// validate names the fields as the source of the config does.
+func (config Config) validate(clientId string, endpoint string, apiKey string, autoCutKey string) error {
+	return validateSettings([]setting{
+		{field: clientId, value: config.ClientId, required: true},
+		{field: endpoint, value: config.Endpoint, required: true, endpoint: true},
+		{field: apiKey, value: config.ApiKey, required: true},
+		{field: autoCutKey, value: config.AutoCutKey, required: true},
+	})
+}
+
+func (config Config) String() string {
+	return fmt.Sprintf("Config{ClientId: %s, TeamId: %s, Endpoint: %s, ApiKey: %s, AutoCutKey: %s}",
+		config.ClientId, config.TeamId, config.Endpoint, redact(config.ApiKey), redact(config.AutoCutKey))
+}
+
+// GoString keeps %#v from printing the keys.
+func (config Config) GoString() string {
+	return config.String()
+}
+
+// LogValue logs the config as a group with the keys redacted.
+func (config Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("client_id", config.ClientId),
+		slog.String("team_id", config.TeamId),
+		slog.String("endpoint", config.Endpoint),
+		slog.String("api_key", redact(config.ApiKey)),
+		slog.String("autocut_key", redact(config.AutoCutKey)))
+}
+
+// redactedConfig has Config's fields and tags but none of its methods, so it can be encoded as is.
+type redactedConfig Config
+
+func (config Config) redacted() redactedConfig {
+	redacted := redactedConfig(config)
+	redacted.ApiKey = redact(config.ApiKey)
+	redacted.AutoCutKey = redact(config.AutoCutKey)
+	return redacted
+}
+
+// MarshalJSON writes the keys redacted. It only affects encoding; FromFile still reads them.
+func (config Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(config.redacted())
+}
+
+// MarshalYAML writes the keys redacted, like MarshalJSON.
+func (config Config) MarshalYAML() (any, error) {
+	return config.redacted(), nil
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// WithConfig sets the client id, team id, endpoint, api key and autocut key from config.
+func WithConfig(config Config) Option {
+	return func(options *libraryOptions) {
+		options.clientId = config.ClientId
+		options.teamId = config.TeamId
+		options.endpoint = config.Endpoint
+		options.apiKey = config.ApiKey
+		options.autoCutKey = config.AutoCutKey
+	}
+}
@@ -0,0 +1,177 @@
+package ticket_library_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticket_library"
+	"gopkg.in/yaml.v3"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// configErrorFields lists the Field of every *ConfigError joined into err.
+func configErrorFields(err error) []string {
+	var fields []string
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		var configError *ticket_library.ConfigError
+		if errors.As(err, &configError) {
+			fields = append(fields, configError.Field)
+		}
+		return fields
+	}
+	for _, problem := range joined.Unwrap() {
+		var configError *ticket_library.ConfigError
+		if errors.As(problem, &configError) {
+			fields = append(fields, configError.Field)
+		}
+	}
+	return fields
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantFields []string
+	}{
+		{
+			name: "complete",
+			env: map[string]string{
+				ticket_library.EnvClientId: "client", ticket_library.EnvEndpoint: "https://tickets.example.com",
+				ticket_library.EnvApiKey: "api-key", ticket_library.EnvAutoCutKey: "autocut",
+			},
+		},
+		{
+			name:       "empty",
+			env:        map[string]string{},
+			wantFields: []string{ticket_library.EnvClientId, ticket_library.EnvEndpoint, ticket_library.EnvApiKey, ticket_library.EnvAutoCutKey},
+		},
+		{
+			name: "invalid endpoint",
+			env: map[string]string{
+				ticket_library.EnvClientId: "client", ticket_library.EnvEndpoint: "tickets.example.com",
+				ticket_library.EnvApiKey: "api-key", ticket_library.EnvAutoCutKey: "autocut",
+			},
+			wantFields: []string{ticket_library.EnvEndpoint},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{
+				ticket_library.EnvClientId, ticket_library.EnvTeamId, ticket_library.EnvEndpoint,
+				ticket_library.EnvApiKey, ticket_library.EnvAutoCutKey,
+			} {
+				t.Setenv(name, test.env[name])
+			}
+			config, err := ticket_library.FromEnv()
+			if fields := configErrorFields(err); fmt.Sprint(fields) != fmt.Sprint(test.wantFields) {
+				t.Errorf("FromEnv reported %v (%v), want %v", fields, err, test.wantFields)
+			}
+			if config.ApiKey != test.env[ticket_library.EnvApiKey] {
+				t.Errorf("api key %q, want %q", config.ApiKey, test.env[ticket_library.EnvApiKey])
+			}
+		})
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		contents   string
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			name: "json",
+			file: "tickets.json",
+			contents: `{"client_id":"client","team_id":"team","endpoint":"https://tickets.example.com",
+				"api_key":"api-key","autocut_key":"autocut"}`,
+		},
+		{
+			name:     "yaml",
+			file:     "tickets.yaml",
+			contents: "client_id: client\nteam_id: team\nendpoint: https://tickets.example.com\napi_key: api-key\nautocut_key: autocut\n",
+		},
+		{
+			name:       "missing keys",
+			file:       "tickets.yaml",
+			contents:   "client_id: client\nendpoint: ftp://tickets.example.com\n",
+			wantFields: []string{"endpoint", "api_key", "autocut_key"},
+		},
+		{
+			name:       "empty yaml",
+			file:       "tickets.yaml",
+			wantFields: []string{"client_id", "endpoint", "api_key", "autocut_key"},
+		},
+		{
+			name:     "unknown json key",
+			file:     "tickets.json",
+			contents: `{"client_id":"client","endpoint":"https://tickets.example.com","apikey":"api-key","autocut_key":"autocut"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "unknown yaml key",
+			file:     "tickets.yml",
+			contents: "client_id: client\nendpoint: https://tickets.example.com\napi_key: api-key\nautocut_key: autocut\nteam: team\n",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.file)
+			if err := os.WriteFile(path, []byte(test.contents), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			config, err := ticket_library.FromFile(path)
+			if test.wantErr {
+				if err == nil || configErrorFields(err) != nil {
+					t.Errorf("FromFile = %v, want a parse error", err)
+				}
+				return
+			}
+			if fields := configErrorFields(err); fmt.Sprint(fields) != fmt.Sprint(test.wantFields) {
+				t.Errorf("FromFile reported %v (%v), want %v", fields, err, test.wantFields)
+			}
+			if err == nil && (config.ClientId != "client" || config.TeamId != "team" || config.ApiKey != "api-key") {
+				t.Errorf("FromFile = %#v, want every key read", config)
+			}
+		})
+	}
+	if _, err := ticket_library.FromFile(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("FromFile(missing) = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestConfigRedactsKeys(t *testing.T) {
+	config := ticket_library.Config{
+		ClientId: "client", Endpoint: "https://tickets.example.com", ApiKey: "secret-api-key", AutoCutKey: "secret-autocut-key",
+	}
+	var logged bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logged, nil)).Info("config", "config", config)
+	jsonEncoded, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yamlEncoded, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, output := range map[string]string{
+		"String": config.String(),
+		"%#v":    fmt.Sprintf("%#v", config),
+		"slog":   logged.String(),
+		"JSON":   string(jsonEncoded),
+		"YAML":   string(yamlEncoded),
+	} {
+		if strings.Contains(output, "secret") || !strings.Contains(output, "[REDACTED]") || !strings.Contains(output, "client") {
+			t.Errorf("%s = %s, want the client id with the keys redacted", name, output)
+		}
+	}
+}
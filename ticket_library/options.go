@@ -0,0 +1,266 @@
+package ticket_library
+
+import (
+	"fmt"
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a TicketLibrary built by NewTicketLibrary or ProvideTicketLibrary.
+type Option func(*libraryOptions)
+
+type libraryOptions struct {
+	clientId                   string
+	teamId                     string
+	endpoint                   string
+	apiKey                     string
+	autoCutKey                 string
+	metricsManager             metrics.MetricsManagerContract
+	logger                     *slog.Logger
+	piiAllowList               []service.PIIKind
+	autocutDeduplicationWindow time.Duration
+	autocutRateLimit           *service.AutocutRateLimit
+	autocutQueueSize           int
+	autocutWorkers             int
+	autocutSpoolDir            string
+	retryPolicy                *service.RetryPolicy
+	circuitBreaker             *service.CircuitBreakerConfig
+	cache                      *service.CacheConfig
+	// passthrough holds service options, such as the HTTP and tracing ones, that every service gets as is.
+	passthrough []service.Option
+}
+
+func applyOptions(opts []Option) libraryOptions {
+	options := libraryOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// serviceOptions returns the options every service of the library is built with, and the circuit
+// breaker, if any. Stateful pieces such as the breaker are created here once so all services share them.
+func (options libraryOptions) serviceOptions(metricsManager metrics.MetricsManagerContract) ([]service.Option, *service.CircuitBreaker) {
+	serviceOptions := append([]service.Option{}, options.passthrough...)
+	if options.logger != nil {
+		serviceOptions = append(serviceOptions, service.WithLogger(options.logger))
+	}
+	if len(options.piiAllowList) > 0 {
+		serviceOptions = append(serviceOptions, service.WithPIIAllowList(options.piiAllowList...))
+	}
+	if options.retryPolicy != nil {
+		serviceOptions = append(serviceOptions, service.WithRetryPolicy(*options.retryPolicy))
+	}
+	var breaker *service.CircuitBreaker
+	if options.circuitBreaker != nil {
+		breaker = service.NewCircuitBreaker(*options.circuitBreaker, metricsManager)
+		breaker.SetLogger(options.logger)
+		serviceOptions = append(serviceOptions, service.WithCircuitBreaker(breaker))
+	}
+	return serviceOptions, breaker
+}
+
+func WithClientId(clientId string) Option {
+	return func(options *libraryOptions) {
+		options.clientId = clientId
+	}
+}
+
+func WithTeamId(teamId string) Option {
+	return func(options *libraryOptions) {
+		options.teamId = teamId
+	}
+}
+
+// WithEndpoint sets the CincinnatiTicketService URL, e.g. "https://tickets.cincinnatiai.com/api".
+func WithEndpoint(endpoint string) Option {
+	return func(options *libraryOptions) {
+		options.endpoint = endpoint
+	}
+}
+
+func WithApiKey(apiKey string) Option {
+	return func(options *libraryOptions) {
+		options.apiKey = apiKey
+	}
+}
+
+func WithAutoCutKey(autoCutKey string) Option {
+	return func(options *libraryOptions) {
+		options.autoCutKey = autoCutKey
+	}
+}
+
+// WithMetricsManager sends call latencies, errors and state changes to metricsManager. Without it
+// NewTicketLibrary discards them.
+func WithMetricsManager(metricsManager metrics.MetricsManagerContract) Option {
+	return func(options *libraryOptions) {
+		options.metricsManager = metricsManager
+	}
+}
+
+// WithLogger sends structured records from every service, the autocut queue, spool and circuit
+// breaker to logger. Filter them with the handler's level; without this option the library is silent.
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *libraryOptions) {
+		options.logger = logger
+	}
+}
+
+// WithPIIAllowList lets the given kinds of personal data through to logs and error messages, e.g.
+// service.PIIUserId while debugging. Everything is redacted by default.
+func WithPIIAllowList(kinds ...service.PIIKind) Option {
+	return func(options *libraryOptions) {
+		options.piiAllowList = append(options.piiAllowList, kinds...)
+	}
+}
+
+// WithAutocutDeduplication suppresses autocuts that repeat the title, description, severity and team
+// of one opened within window. Repeats are added as comments on the open ticket instead.
+func WithAutocutDeduplication(window time.Duration) Option {
+	return func(options *libraryOptions) {
+		options.autocutDeduplicationWindow = window
+	}
+}
+
+// WithAutocutRateLimit caps autocuts per minute, overall and per severity. See
+// service.DefaultAutocutRateLimit for a starting point.
+func WithAutocutRateLimit(limit service.AutocutRateLimit) Option {
+	return func(options *libraryOptions) {
+		options.autocutRateLimit = &limit
+	}
+}
+
+// WithAsyncAutocut enables TicketLibrary.CreateAutocutAsync, which buffers up to bufferSize autocuts
+// and sends them from `workers` goroutines. Call Close on shutdown to drain the buffer.
+func WithAsyncAutocut(bufferSize int, workers int) Option {
+	return func(options *libraryOptions) {
+		options.autocutQueueSize = bufferSize
+		options.autocutWorkers = workers
+	}
+}
+
+// WithAutocutSpool keeps autocuts that fail with a retryable status in dir and replays them in the
+// background with backoff, including ones left behind by a previous run. Close stops the replay.
+func WithAutocutSpool(dir string) Option {
+	return func(options *libraryOptions) {
+		options.autocutSpoolDir = dir
+	}
+}
+
+// autocutSpool opens the directory given to WithAutocutSpool, or returns nil when there is none.
+func (options libraryOptions) autocutSpool() (*service.AutocutSpool, error) {
+	if options.autocutSpoolDir == "" {
+		return nil, nil
+	}
+	spool, err := service.NewAutocutSpool(options.autocutSpoolDir)
+	if err != nil {
+		return nil, &ConfigError{Field: "autocut spool dir", Reason: fmt.Sprintf("cannot be used: %v", err)}
+	}
+	spool.SetLogger(options.logger)
+	return spool, nil
+}
+
+// WithRetryPolicy retries failed calls of every service with policy. See service.DefaultRetryPolicy
+// for a starting point; without this option each call is attempted once.
+func WithRetryPolicy(policy service.RetryPolicy) Option {
+	return func(options *libraryOptions) {
+		options.retryPolicy = &policy
+	}
+}
+
+// WithCircuitBreaker puts one circuit breaker in front of all five services. After
+// config.FailureThreshold consecutive failures calls fail fast with service.ErrCircuitOpen until a
+// probe succeeds. See service.DefaultCircuitBreakerConfig.
+func WithCircuitBreaker(config service.CircuitBreakerConfig) Option {
+	return func(options *libraryOptions) {
+		options.circuitBreaker = &config
+	}
+}
+
+// WithCache puts a read-through cache, bounded by config, in front of the Fetch methods of the ticket,
+// team and member services. The library's own Update and Delete calls drop the rows they touch; writes
+// made by other processes show up once config.TTL passes. See service.DefaultCacheConfig.
+func WithCache(config service.CacheConfig) Option {
+	return func(options *libraryOptions) {
+		options.cache = &config
+	}
+}
+
+// WithHTTPClient makes all five services share httpClient, and with it its transport: proxies, TLS
+// roots, client certificates and the connection pool.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithHTTPClient(httpClient))
+	}
+}
+
+// WithTimeout bounds every attempt made by any service.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithTimeout(timeout))
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithUserAgent(userAgent))
+	}
+}
+
+// WithHeaders adds headers to every request of every service.
+func WithHeaders(headers http.Header) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithHeaders(headers))
+	}
+}
+
+// WithTracerProvider creates a client span for every call of every service from provider. Without it
+// the global provider from otel.SetTracerProvider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithTracerProvider(provider))
+	}
+}
+
+// WithPropagator replaces the W3C trace context propagation used for every request.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithPropagator(propagator))
+	}
+}
+
+// WithFetchConcurrency bounds how many tickets TicketService.FetchMany fetches at once.
+func WithFetchConcurrency(concurrency int) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithFetchConcurrency(concurrency))
+	}
+}
+
+// cached puts the caches from WithCache in front of the services that have them.
+func (options libraryOptions) cached(
+	ticketService service.TicketServiceContract,
+	teamService service.TicketTeamServiceContract,
+	memberService service.TicketTeamMemberServiceContract,
+	metricsManager metrics.MetricsManagerContract,
+) (service.TicketServiceContract, service.TicketTeamServiceContract, service.TicketTeamMemberServiceContract) {
+	if options.cache == nil {
+		return ticketService, teamService, memberService
+	}
+	return service.NewCachedTicketService(ticketService, *options.cache, metricsManager),
+		service.NewCachedTicketTeamService(teamService, *options.cache, metricsManager),
+		service.NewCachedTicketTeamMemberService(memberService, *options.cache, metricsManager)
+}
+
+// WithTicketTransitions replaces the state machine TicketService.Transition enforces. See
+// service.DefaultTicketTransitions.
+func WithTicketTransitions(transitions service.TicketTransitions) Option {
+	return func(options *libraryOptions) {
+		options.passthrough = append(options.passthrough, service.WithTicketTransitions(transitions))
+	}
+}
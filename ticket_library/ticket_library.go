@@ -1,8 +1,11 @@
 package ticket_library
 
 import (
+	"context"
 	"github.com/nicholaspark09/awsgorocket/metrics"
 	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics"
+	"log/slog"
 )
 
 type TicketLibrary struct {
@@ -11,35 +14,242 @@ type TicketLibrary struct {
 	ticketEndpoint          string
 	ticketApiKey            string
 	autoCutKey              string
-	TicketService           service.TicketService
-	TicketCommentService    service.TicketCommentService
-	TicketWatchService      service.TicketWatchService
-	TicketTeamService       service.TicketTeamService
-	TicketTeamMemberService service.TicketTeamMemberService
+	autocutQueue            *service.AutocutQueue
+	stopSpoolReplay         context.CancelFunc
+	spoolReplayStopped      chan struct{}
+	circuitBreaker          *service.CircuitBreaker
+	TicketService           service.TicketServiceContract
+	TicketCommentService    service.TicketCommentServiceContract
+	TicketWatchService      service.TicketWatchServiceContract
+	TicketTeamService       service.TicketTeamServiceContract
+	TicketTeamMemberService service.TicketTeamMemberServiceContract
 }
 
+// NewTicketLibrary builds a TicketLibrary from options, e.g.
+//
+//	ticket_library.NewTicketLibrary(
+//		ticket_library.WithClientId(clientId),
+//		ticket_library.WithTeamId(teamId),
+//		ticket_library.WithEndpoint(endpoint),
+//		ticket_library.WithApiKey(apiKey),
+//		ticket_library.WithAutoCutKey(autoCutKey),
+//	)
+//
+// The client id, endpoint, api key and autocut key are required and the endpoint must be an http(s)
+// URL; every problem found is reported in the returned error. Without WithMetricsManager metrics are
+// discarded.
+func NewTicketLibrary(opts ...Option) (*TicketLibrary, error) {
+	options := applyOptions(opts)
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	spool, err := options.autocutSpool()
+	if err != nil {
+		return nil, err
+	}
+	metricsManager := options.metricsManager
+	if metricsManager == nil {
+		metricsManager = ticketmetrics.NoopMetricsManager{}
+	}
+	ticketLibrary := provideTicketLibrary(
+		options.clientId,
+		options.teamId,
+		options.endpoint,
+		options.apiKey,
+		options.autoCutKey,
+		metricsManager,
+		options,
+		spool)
+	return &ticketLibrary, nil
+}
+
+// ProvideTicketLibrary builds a TicketLibrary from positional arguments without validating them.
+// NewTicketLibrary is preferred for new code; the identity options such as WithClientId are ignored here.
+// A spool directory that cannot be created is logged, to slog.Default() when there is no WithLogger, and
+// the library runs without a spool.
 func ProvideTicketLibrary(
 	clientId string,
 	teamId string,
 	ticketEndpoint string,
 	ticketApiKey string,
 	autoCutKey string,
-	metricsManager metrics.MetricsManagerContract) TicketLibrary {
+	metricsManager metrics.MetricsManagerContract,
+	opts ...Option) TicketLibrary {
+	options := applyOptions(opts)
+	spool, err := options.autocutSpool()
+	if err != nil {
+		logger := options.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Error("SPOOL_ERROR", "method", "ProvideTicketLibrary", "error", err)
+	}
+	return provideTicketLibrary(clientId, teamId, ticketEndpoint, ticketApiKey, autoCutKey, metricsManager, options, spool)
+}
+
+func provideTicketLibrary(
+	clientId string,
+	teamId string,
+	ticketEndpoint string,
+	ticketApiKey string,
+	autoCutKey string,
+	metricsManager metrics.MetricsManagerContract,
+	options libraryOptions,
+	spool *service.AutocutSpool) TicketLibrary {
+	serviceOptions, circuitBreaker := options.serviceOptions(metricsManager)
+	commentService := service.ProvideTicketCommentService(ticketEndpoint, ticketApiKey, metricsManager, serviceOptions...)
+	watchService := service.ProvideTicketWatchService(ticketEndpoint, ticketApiKey, metricsManager, serviceOptions...)
+	ticketOptions := append([]service.Option{}, serviceOptions...)
+	ticketOptions = append(ticketOptions, service.WithTicketWatchService(&watchService))
+	if options.autocutDeduplicationWindow > 0 {
+		ticketOptions = append(ticketOptions, service.WithAutocutDeduplication(options.autocutDeduplicationWindow, &commentService))
+	}
+	if options.autocutRateLimit != nil {
+		ticketOptions = append(ticketOptions, service.WithAutocutRateLimit(*options.autocutRateLimit))
+	}
+	if spool != nil {
+		ticketOptions = append(ticketOptions, service.WithAutocutSpool(spool))
+	}
+	ticketService := service.ProvideTicketService(
+		ticketEndpoint,
+		ticketApiKey,
+		clientId,
+		teamId,
+		autoCutKey,
+		metricsManager,
+		ticketOptions...)
+	teamService := service.ProvideTicketTeamService(ticketEndpoint, ticketApiKey, metricsManager, serviceOptions...)
+	memberService := service.ProvideTicketTeamMemberService(ticketEndpoint, ticketApiKey, metricsManager, serviceOptions...)
+	var stopSpoolReplay context.CancelFunc
+	var spoolReplayStopped chan struct{}
+	if spool != nil {
+		stopSpoolReplay, spoolReplayStopped = startSpoolReplay(&ticketService)
+	}
+	cachedTicketService, cachedTeamService, cachedMemberService := options.cached(&ticketService, &teamService, &memberService, metricsManager)
+	return TicketLibrary{
+		clientId:                clientId,
+		teamId:                  teamId,
+		ticketEndpoint:          ticketEndpoint,
+		ticketApiKey:            ticketApiKey,
+		autoCutKey:              autoCutKey,
+		autocutQueue:            provideAutocutQueue(options, &ticketService, metricsManager),
+		stopSpoolReplay:         stopSpoolReplay,
+		spoolReplayStopped:      spoolReplayStopped,
+		circuitBreaker:          circuitBreaker,
+		TicketService:           cachedTicketService,
+		TicketCommentService:    &commentService,
+		TicketWatchService:      &watchService,
+		TicketTeamService:       cachedTeamService,
+		TicketTeamMemberService: cachedMemberService,
+	}
+}
+
+// ProvideTicketLibraryWithServices builds a TicketLibrary around existing service implementations,
+// e.g. the in-memory fakes from the tickettest package. Options that configure the HTTP services,
+// such as deduplication and rate limiting, do not apply here; WithCache does.
+func ProvideTicketLibraryWithServices(
+	clientId string,
+	teamId string,
+	ticketService service.TicketServiceContract,
+	commentService service.TicketCommentServiceContract,
+	watchService service.TicketWatchServiceContract,
+	teamService service.TicketTeamServiceContract,
+	memberService service.TicketTeamMemberServiceContract,
+	opts ...Option) TicketLibrary {
+	options := applyOptions(opts)
+	ticketService, teamService, memberService = options.cached(ticketService, teamService, memberService, nil)
 	return TicketLibrary{
-		clientId:       clientId,
-		teamId:         teamId,
-		ticketEndpoint: ticketEndpoint,
-		ticketApiKey:   ticketApiKey,
-		TicketService: service.ProvideTicketService(
-			ticketEndpoint,
-			ticketApiKey,
-			clientId,
-			teamId,
-			autoCutKey,
-			metricsManager),
-		TicketCommentService:    service.ProvideTicketCommentService(ticketEndpoint, ticketApiKey, metricsManager),
-		TicketWatchService:      service.ProvideTicketWatchService(ticketEndpoint, ticketApiKey, metricsManager),
-		TicketTeamService:       service.ProvideTicketTeamService(ticketEndpoint, ticketApiKey, metricsManager),
-		TicketTeamMemberService: service.ProvideTicketTeamMemberService(ticketEndpoint, ticketApiKey, metricsManager),
+		clientId:                clientId,
+		teamId:                  teamId,
+		autocutQueue:            provideAutocutQueue(options, ticketService, nil),
+		TicketService:           ticketService,
+		TicketCommentService:    commentService,
+		TicketWatchService:      watchService,
+		TicketTeamService:       teamService,
+		TicketTeamMemberService: memberService,
+	}
+}
+
+func provideAutocutQueue(
+	options libraryOptions,
+	ticketService service.TicketServiceContract,
+	metricsManager metrics.MetricsManagerContract) *service.AutocutQueue {
+	if options.autocutQueueSize <= 0 {
+		return nil
+	}
+	queue := service.NewAutocutQueue(ticketService, metricsManager, options.autocutQueueSize, options.autocutWorkers)
+	queue.SetLogger(options.logger)
+	return queue
+}
+
+func startSpoolReplay(ticketService *service.TicketService) (context.CancelFunc, chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticketService.RunAutocutSpool(ctx)
+	}()
+	return cancel, stopped
+}
+
+// CreateAutocutAsync hands the autocut to the background queue and returns immediately; false means
+// it was dropped. Without WithAsyncAutocut it falls back to a synchronous TicketService.CreateAutocut.
+func (ticketLibrary *TicketLibrary) CreateAutocutAsync(title string, description string, files string, severity int) bool {
+	if ticketLibrary.autocutQueue == nil {
+		return ticketLibrary.TicketService.CreateAutocut(title, description, files, severity)
+	}
+	return ticketLibrary.autocutQueue.Enqueue(title, description, files, severity)
+}
+
+// AutocutQueueDepth reports how many async autocuts are waiting to be sent.
+func (ticketLibrary *TicketLibrary) AutocutQueueDepth() int {
+	if ticketLibrary.autocutQueue == nil {
+		return 0
+	}
+	return ticketLibrary.autocutQueue.Depth()
+}
+
+// AutocutsDropped reports how many async autocuts were dropped because the queue was full.
+func (ticketLibrary *TicketLibrary) AutocutsDropped() int64 {
+	if ticketLibrary.autocutQueue == nil {
+		return 0
+	}
+	return ticketLibrary.autocutQueue.Dropped()
+}
+
+// CircuitState reports the state of the breaker set up by WithCircuitBreaker. Without one the
+// circuit is always closed.
+func (ticketLibrary *TicketLibrary) CircuitState() service.CircuitState {
+	if ticketLibrary.circuitBreaker == nil {
+		return service.CircuitClosed
+	}
+	return ticketLibrary.circuitBreaker.State()
+}
+
+// Flush waits for queued autocuts to be sent.
+func (ticketLibrary *TicketLibrary) Flush(ctx context.Context) error {
+	if ticketLibrary.autocutQueue == nil {
+		return nil
+	}
+	return ticketLibrary.autocutQueue.Flush(ctx)
+}
+
+// Close drains the autocut queue, stops its workers and stops replaying the autocut spool.
+// Call it once during shutdown.
+func (ticketLibrary *TicketLibrary) Close(ctx context.Context) error {
+	var err error
+	if ticketLibrary.autocutQueue != nil {
+		err = ticketLibrary.autocutQueue.Close(ctx)
+	}
+	if ticketLibrary.stopSpoolReplay != nil {
+		ticketLibrary.stopSpoolReplay()
+		select {
+		case <-ticketLibrary.spoolReplayStopped:
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+		}
 	}
+	return err
 }
@@ -0,0 +1,258 @@
+package ticket_library_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticket_library"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/tickettest"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newServedLibrary returns a TicketLibrary talking HTTP to a stand-in server over backend.
+func newServedLibrary(t *testing.T, backend *tickettest.Backend, opts ...ticket_library.Option) *ticket_library.TicketLibrary {
+	t.Helper()
+	server := tickettest.NewServer(backend, "api-key")
+	t.Cleanup(server.Close)
+	opts = append([]ticket_library.Option{
+		ticket_library.WithClientId("client"),
+		ticket_library.WithTeamId("team"),
+		ticket_library.WithEndpoint(server.URL),
+		ticket_library.WithApiKey("api-key"),
+		ticket_library.WithAutoCutKey("autocut"),
+		ticket_library.WithRetryPolicy(service.RetryPolicy{
+			MaxAttempts:          3,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}),
+	}, opts...)
+	ticketLibrary, err := ticket_library.NewTicketLibrary(opts...)
+	if err != nil {
+		t.Fatalf("NewTicketLibrary: %v", err)
+	}
+	return ticketLibrary
+}
+
+func TestTicketLibraryOverHTTP(t *testing.T) {
+	ctx := context.Background()
+	backend := tickettest.NewBackend()
+	ticketLibrary := newServedLibrary(t, backend)
+
+	created, err := ticketLibrary.TicketService.CreateAutocutWithError(ctx, "Disk full", "/var is at 100%", "", 2)
+	if err != nil {
+		t.Fatalf("CreateAutocut: %v", err)
+	}
+	if created.PartitionKey != "client_team" || created.UserId != "autocut" {
+		t.Errorf("created %+v, want partition client_team by autocut", created)
+	}
+	fetched, err := ticketLibrary.TicketService.FetchWithError(ctx, created.PartitionKey, created.RangeKey)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if fetched.Title != "Disk full" || fetched.Description != "/var is at 100%" || fetched.Severity != 2 {
+		t.Errorf("fetched %+v, want the created ticket", fetched)
+	}
+
+	t.Run("paging", func(t *testing.T) {
+		for _, title := range []string{"Two", "Three", "Four", "Five"} {
+			if _, err := ticketLibrary.TicketService.CreateAutocutWithError(ctx, title, "", "", 3); err != nil {
+				t.Fatalf("CreateAutocut(%s): %v", title, err)
+			}
+		}
+		backend.SetPageSize(2)
+		fetchAllRequest := ticket_model_request.TicketModelFetchAllRequest{ClientId: "client", TeamId: "team"}
+		firstPage, err := ticketLibrary.TicketService.FetchAllWithError(ctx, fetchAllRequest)
+		if err != nil {
+			t.Fatalf("FetchAll: %v", err)
+		}
+		if len(firstPage.Results) != 2 || firstPage.LastRangeKey == nil {
+			t.Errorf("first page has %d results and last range key %v, want 2 and a key", len(firstPage.Results), firstPage.LastRangeKey)
+		}
+		all, err := service.NewTicketPager(ticketLibrary.TicketService, fetchAllRequest).All(ctx, 0)
+		if err != nil || len(all) != 5 {
+			t.Errorf("paged %d tickets, %v; want 5", len(all), err)
+		}
+	})
+
+	t.Run("injected failures", func(t *testing.T) {
+		backend.FailNext("TicketService.Fetch", http.StatusNotFound)
+		if _, err := ticketLibrary.TicketService.FetchWithError(ctx, created.PartitionKey, created.RangeKey); !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("Fetch after FailNext(404) = %v, want ErrNotFound", err)
+		}
+		if _, err := ticketLibrary.TicketService.FetchWithError(ctx, created.PartitionKey, created.RangeKey); err != nil {
+			t.Errorf("Fetch after the failure cleared = %v", err)
+		}
+		backend.FailNext("TicketService.Fetch", http.StatusServiceUnavailable)
+		if _, err := ticketLibrary.TicketService.FetchWithError(ctx, created.PartitionKey, created.RangeKey); err != nil {
+			t.Errorf("Fetch after FailNext(503) = %v, want the retry to succeed", err)
+		}
+	})
+
+	t.Run("idempotent create", func(t *testing.T) {
+		createRequest := ticket_comment_request.TicketCommentModelCreateRequest{
+			TicketPartitionKey: created.PartitionKey,
+			TicketRangeKey:     created.RangeKey,
+			UserId:             "u-1",
+			Message:            "on it",
+			IdempotencyKey:     "comment-1",
+		}
+		first, err := ticketLibrary.TicketCommentService.CreateWithError(ctx, createRequest)
+		if err != nil {
+			t.Fatalf("first Create: %v", err)
+		}
+		repeat, err := ticketLibrary.TicketCommentService.CreateWithError(ctx, createRequest)
+		if err != nil {
+			t.Fatalf("repeated Create: %v", err)
+		}
+		if repeat.RangeKey != first.RangeKey || len(backend.Comments()) != 1 {
+			t.Errorf("repeat created %s after %s and %d comments are stored, want the first comment once",
+				repeat.RangeKey, first.RangeKey, len(backend.Comments()))
+		}
+	})
+}
+
+func TestTicketLibraryRejectsAWrongApiKey(t *testing.T) {
+	backend := tickettest.NewBackend()
+	server := tickettest.NewServer(backend, "api-key")
+	defer server.Close()
+	ticketLibrary, err := ticket_library.NewTicketLibrary(
+		ticket_library.WithClientId("client"),
+		ticket_library.WithEndpoint(server.URL),
+		ticket_library.WithApiKey("wrong-key"),
+		ticket_library.WithAutoCutKey("autocut"),
+	)
+	if err != nil {
+		t.Fatalf("NewTicketLibrary: %v", err)
+	}
+	if _, err := ticketLibrary.TicketService.FetchWithError(context.Background(), "pk", "rk"); !errors.Is(err, service.ErrUnauthorized) {
+		t.Errorf("Fetch = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestProvideTicketLibraryLogsASpoolErrorWithoutALogger(t *testing.T) {
+	var logged bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logged, nil)))
+	defer slog.SetDefault(previous)
+	notADirectory := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notADirectory, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ticket_library.ProvideTicketLibrary("client", "team", "https://tickets.example.com", "api-key", "autocut",
+		ticketmetrics.NoopMetricsManager{}, ticket_library.WithAutocutSpool(filepath.Join(notADirectory, "spool")))
+	if !strings.Contains(logged.String(), "SPOOL_ERROR") {
+		t.Errorf("default logger got %q, want a SPOOL_ERROR", logged.String())
+	}
+}
+
+func TestNewTicketLibraryValidatesItsArguments(t *testing.T) {
+	valid := []ticket_library.Option{
+		ticket_library.WithClientId("client"),
+		ticket_library.WithEndpoint("https://tickets.example.com"),
+		ticket_library.WithApiKey("api-key"),
+		ticket_library.WithAutoCutKey("autocut"),
+	}
+	tests := []struct {
+		name       string
+		opts       []ticket_library.Option
+		wantFields []string
+	}{
+		{name: "valid", opts: valid},
+		{name: "nothing", wantFields: []string{"client id", "endpoint", "api key", "autocut key"}},
+		{name: "blank client id", opts: append(valid[1:], ticket_library.WithClientId("  ")), wantFields: []string{"client id"}},
+		{name: "endpoint without a scheme", opts: append(valid[:1:1], valid[2], valid[3], ticket_library.WithEndpoint("tickets.example.com")), wantFields: []string{"endpoint"}},
+		{name: "endpoint without a host", opts: append(valid[:1:1], valid[2], valid[3], ticket_library.WithEndpoint("https://")), wantFields: []string{"endpoint"}},
+		{name: "endpoint not http", opts: append(valid[:1:1], valid[2], valid[3], ticket_library.WithEndpoint("ftp://tickets.example.com")), wantFields: []string{"endpoint"}},
+		{name: "missing keys", opts: valid[:2], wantFields: []string{"api key", "autocut key"}},
+		{name: "unusable spool", opts: append(valid[:4:4], ticket_library.WithAutocutSpool("/dev/null/spool")), wantFields: []string{"autocut spool dir"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ticketLibrary, err := ticket_library.NewTicketLibrary(test.opts...)
+			if fields := configErrorFields(err); fmt.Sprint(fields) != fmt.Sprint(test.wantFields) {
+				t.Errorf("NewTicketLibrary reported %v (%v), want %v", fields, err, test.wantFields)
+			}
+			if (ticketLibrary == nil) != (test.wantFields != nil) {
+				t.Errorf("NewTicketLibrary = %v, %v; want a library only without errors", ticketLibrary, err)
+			}
+		})
+	}
+}
+
+func TestTicketLibraryIdentity(t *testing.T) {
+	backend := tickettest.NewBackend()
+	server := tickettest.NewServer(backend, "api-key")
+	defer server.Close()
+	config := ticket_library.Config{ClientId: "config-client", TeamId: "config-team", Endpoint: server.URL, ApiKey: "api-key", AutoCutKey: "config-autocut"}
+	newLibrary := func(opts ...ticket_library.Option) *ticket_library.TicketLibrary {
+		ticketLibrary, err := ticket_library.NewTicketLibrary(opts...)
+		if err != nil {
+			t.Fatalf("NewTicketLibrary: %v", err)
+		}
+		return ticketLibrary
+	}
+	provided := func(opts ...ticket_library.Option) *ticket_library.TicketLibrary {
+		ticketLibrary := ticket_library.ProvideTicketLibrary("positional-client", "positional-team", server.URL, "api-key",
+			"positional-autocut", ticketmetrics.NoopMetricsManager{}, opts...)
+		return &ticketLibrary
+	}
+	tests := []struct {
+		name          string
+		library       func() *ticket_library.TicketLibrary
+		wantPartition string
+		wantUserId    string
+	}{
+		{
+			name:          "config",
+			library:       func() *ticket_library.TicketLibrary { return newLibrary(ticket_library.WithConfig(config)) },
+			wantPartition: "config-client_config-team",
+			wantUserId:    "config-autocut",
+		},
+		{
+			name: "later options override the config",
+			library: func() *ticket_library.TicketLibrary {
+				return newLibrary(ticket_library.WithConfig(config), ticket_library.WithTeamId("option-team"), ticket_library.WithAutoCutKey("option-autocut"))
+			},
+			wantPartition: "config-client_option-team",
+			wantUserId:    "option-autocut",
+		},
+		{
+			name: "a later config overrides options",
+			library: func() *ticket_library.TicketLibrary {
+				return newLibrary(ticket_library.WithTeamId("option-team"), ticket_library.WithConfig(config))
+			},
+			wantPartition: "config-client_config-team",
+			wantUserId:    "config-autocut",
+		},
+		{
+			name: "ProvideTicketLibrary ignores identity options",
+			library: func() *ticket_library.TicketLibrary {
+				return provided(ticket_library.WithClientId("option-client"), ticket_library.WithConfig(config))
+			},
+			wantPartition: "positional-client_positional-team",
+			wantUserId:    "positional-autocut",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			created, err := test.library().TicketService.CreateAutocutWithError(context.Background(), test.name, "", "", 2)
+			if err != nil {
+				t.Fatalf("CreateAutocut: %v", err)
+			}
+			if created.PartitionKey != test.wantPartition || created.UserId != test.wantUserId {
+				t.Errorf("created in %s by %s, want %s by %s", created.PartitionKey, created.UserId, test.wantPartition, test.wantUserId)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Package ticketmetrics holds metrics.MetricsManagerContract implementations for the ticket library.
+// The Prometheus adapter lives in the prommetrics subpackage so only callers who use it depend on
+// the Prometheus client.
+package ticketmetrics
+
+import (
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	"time"
+)
+
+// NoopMetricsManager discards every metric, for callers who do not want metrics at all.
+type NoopMetricsManager struct{}
+
+var _ metrics.MetricsManagerContract = NoopMetricsManager{}
+
+func (NoopMetricsManager) SendMeasuredTime(string, time.Duration) {}
+
+func (NoopMetricsManager) SendLog(string, string) {}
+
+func (NoopMetricsManager) Send500Error(string, int, string) {}
+
+func (NoopMetricsManager) Send400Error(string, int, string) {}
@@ -0,0 +1,129 @@
+// Package prommetrics exports the ticket library's metrics to Prometheus.
+package prommetrics
+
+import (
+	"github.com/nicholaspark09/awsgorocket/metrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+// DefaultNamespace prefixes every metric name unless New is given another namespace.
+const DefaultNamespace = "cincinnati_ticket"
+
+// MetricsManager implements metrics.MetricsManagerContract with Prometheus collectors, labelled by the
+// method names the services report, e.g. "TicketService.Fetch" or "CincinnatiTicketService.create":
+//
+//	<namespace>_requests_total{method}
+//	<namespace>_request_duration_seconds{method}
+//	<namespace>_errors_total{method, status_code}
+//	<namespace>_events_total{method}
+//	<namespace>_circuit_breaker_state{method}
+//	<namespace>_cache_lookups_total{cache, result}
+//
+// Events count SendLog calls. The circuit breaker state is a gauge: 0 closed, 1 open, 2 half-open.
+// Cache lookups are labelled "hit" or "miss".
+type MetricsManager struct {
+	requests     *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	errors       *prometheus.CounterVec
+	events       *prometheus.CounterVec
+	circuitState *prometheus.GaugeVec
+	cacheLookups *prometheus.CounterVec
+}
+
+var (
+	_ metrics.MetricsManagerContract = (*MetricsManager)(nil)
+	_ service.CircuitStateSender     = (*MetricsManager)(nil)
+	_ service.CacheLookupSender      = (*MetricsManager)(nil)
+)
+
+// New registers the collectors on registerer, e.g. prometheus.DefaultRegisterer. An empty namespace
+// means DefaultNamespace. It fails if the collectors are already registered, e.g. by a second
+// library using the same namespace.
+func New(registerer prometheus.Registerer, namespace string) (*MetricsManager, error) {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	metricsManager := &MetricsManager{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Ticket service calls made, by method.",
+		}, []string{"method"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by ticket service calls, retries included, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Failed ticket service calls, by method and status code.",
+		}, []string{"method", "status_code"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_total",
+			Help:      "Notable library events, by source.",
+		}, []string{"method"}),
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_state",
+			Help:      "Current circuit breaker state: 0 closed, 1 open, 2 half-open.",
+		}, []string{"method"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Read-through cache lookups, by cache and result.",
+		}, []string{"cache", "result"}),
+	}
+	collectors := []prometheus.Collector{
+		metricsManager.requests,
+		metricsManager.latency,
+		metricsManager.errors,
+		metricsManager.events,
+		metricsManager.circuitState,
+		metricsManager.cacheLookups,
+	}
+	for index, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			for _, registered := range collectors[:index] {
+				registerer.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+	return metricsManager, nil
+}
+
+func (metricsManager *MetricsManager) SendMeasuredTime(callName string, timeDuration time.Duration) {
+	metricsManager.requests.WithLabelValues(callName).Inc()
+	metricsManager.latency.WithLabelValues(callName).Observe(timeDuration.Seconds())
+}
+
+// SendLog counts the event; the message is not exported since it would make every label unique.
+func (metricsManager *MetricsManager) SendLog(callName string, message string) {
+	metricsManager.events.WithLabelValues(callName).Inc()
+}
+
+func (metricsManager *MetricsManager) Send500Error(callName string, statusCode int, message string) {
+	metricsManager.errors.WithLabelValues(callName, strconv.Itoa(statusCode)).Inc()
+}
+
+func (metricsManager *MetricsManager) Send400Error(callName string, statusCode int, message string) {
+	metricsManager.errors.WithLabelValues(callName, strconv.Itoa(statusCode)).Inc()
+}
+
+func (metricsManager *MetricsManager) SendCircuitState(callName string, state int) {
+	metricsManager.circuitState.WithLabelValues(callName).Set(float64(state))
+}
+
+func (metricsManager *MetricsManager) SendCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	metricsManager.cacheLookups.WithLabelValues(cache, result).Inc()
+}
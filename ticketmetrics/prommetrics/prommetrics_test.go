@@ -0,0 +1,74 @@
+package prommetrics_test
+
+import (
+	"context"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticketmetrics/prommetrics"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/tickettest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsManagerCountsFailedCallsOnce(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metricsManager, err := prommetrics.New(registry, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	backend := tickettest.NewBackend()
+	server := tickettest.NewServer(backend, "api-key")
+	defer server.Close()
+	policy := service.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	ticketService := service.ProvideTicketService(server.URL, "api-key", "client", "team", "autocut",
+		metricsManager, service.WithRetryPolicy(policy))
+	fetchAll := ticket_model_request.TicketModelFetchAllRequest{ClientId: "client", TeamId: "team"}
+
+	// A 4xx is not retried.
+	backend.FailNext("TicketService.FetchAll", http.StatusNotFound)
+	if _, err := ticketService.FetchAllWithError(context.Background(), fetchAll); err == nil {
+		t.Fatal("FetchAll succeeded despite the 404")
+	}
+	// A 5xx is retried until the policy gives up and counted once, with the status it finally failed with.
+	backend.FailTimes("TicketService.FetchAll", http.StatusServiceUnavailable, policy.MaxAttempts)
+	if _, err := ticketService.FetchAllWithError(context.Background(), fetchAll); err == nil {
+		t.Fatal("FetchAll succeeded despite the 503s")
+	}
+	// A call that succeeds on a retry is not an error.
+	backend.FailNext("TicketService.FetchAll", http.StatusServiceUnavailable)
+	if _, err := ticketService.FetchAllWithError(context.Background(), fetchAll); err != nil {
+		t.Fatalf("FetchAll after one 503: %v", err)
+	}
+
+	want := `
+# HELP cincinnati_ticket_errors_total Failed ticket service calls, by method and status code.
+# TYPE cincinnati_ticket_errors_total counter
+cincinnati_ticket_errors_total{method="TicketService.FetchAll",status_code="404"} 1
+cincinnati_ticket_errors_total{method="TicketService.FetchAll",status_code="503"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "cincinnati_ticket_errors_total"); err != nil {
+		t.Error(err)
+	}
+	if requests := testutil.CollectAndCount(registry, "cincinnati_ticket_requests_total"); requests != 1 {
+		t.Errorf("%d request series, want 1 for TicketService.FetchAll", requests)
+	}
+}
+
+func TestNewRejectsASecondRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if _, err := prommetrics.New(registry, ""); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := prommetrics.New(registry, ""); err == nil {
+		t.Fatal("second New on the same registry succeeded")
+	}
+	// The failed registration leaves nothing behind, so another namespace still fits.
+	if _, err := prommetrics.New(registry, "other"); err != nil {
+		t.Errorf("New with another namespace: %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+// Package tickettest provides an in-memory stand-in for CincinnatiTicketService so code built on
+// ticket_library.TicketLibrary can be tested without reaching the hosted endpoint.
+package tickettest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/awsgorocket/utils"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultPageSize = 25
+
+// Backend stores tickets, comments, watches, teams and members the way the hosted service keys them.
+// It is safe for concurrent use. Failures can be injected per method name, e.g. "TicketService.Fetch".
+type Backend struct {
+	mu       sync.Mutex
+	pageSize int
+	sequence int64
+	now      func() time.Time
+	tickets  map[string]*ticketRow
+	comments map[string]*commentRow
+	watches  map[string]*watchRow
+	teams    map[string]*teamRow
+	members  map[string]*memberRow
+	failures map[string]*failure
+	// idempotencyMu serializes keyed creates; it is always taken before mu.
+	idempotencyMu sync.Mutex
+	idempotent    map[string]any
+}
+
+type failure struct {
+	statusCode int
+	// remaining < 0 means the failure never clears on its own
+	remaining int
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		pageSize:   defaultPageSize,
+		now:        time.Now,
+		tickets:    map[string]*ticketRow{},
+		comments:   map[string]*commentRow{},
+		watches:    map[string]*watchRow{},
+		teams:      map[string]*teamRow{},
+		members:    map[string]*memberRow{},
+		failures:   map[string]*failure{},
+		idempotent: map[string]any{},
+	}
+}
+
+// SetPageSize changes how many results a list call returns before handing back paging keys.
+func (backend *Backend) SetPageSize(pageSize int) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	backend.pageSize = pageSize
+}
+
+// SetClock replaces the time source used for keys and Created/Modified stamps.
+func (backend *Backend) SetClock(now func() time.Time) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.now = now
+}
+
+// FailNext makes the next call to methodName fail with statusCode.
+func (backend *Backend) FailNext(methodName string, statusCode int) {
+	backend.FailTimes(methodName, statusCode, 1)
+}
+
+// FailTimes makes the next count calls to methodName fail with statusCode.
+func (backend *Backend) FailTimes(methodName string, statusCode int, count int) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.failures[methodName] = &failure{statusCode: statusCode, remaining: count}
+}
+
+// FailAlways makes every call to methodName fail with statusCode until ClearFailures is called.
+func (backend *Backend) FailAlways(methodName string, statusCode int) {
+	backend.FailTimes(methodName, statusCode, -1)
+}
+
+func (backend *Backend) ClearFailures() {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.failures = map[string]*failure{}
+}
+
+// injectedFailure reports the status code of a pending failure for methodName, consuming it.
+func (backend *Backend) injectedFailure(methodName string) (int, bool) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	pending, ok := backend.failures[methodName]
+	if !ok {
+		return 0, false
+	}
+	if pending.remaining > 0 {
+		pending.remaining--
+		if pending.remaining == 0 {
+			delete(backend.failures, methodName)
+		}
+	}
+	return pending.statusCode, true
+}
+
+// nextRangeKey returns a time ordered key that sorts the same way the service's Time.UUID keys do.
+// Callers must hold backend.mu.
+func (backend *Backend) nextRangeKey() string {
+	backend.sequence++
+	return fmt.Sprintf("%019d-%06d", backend.now().UnixNano(), backend.sequence)
+}
+
+// timestamp must be called with backend.mu held.
+func (backend *Backend) timestamp() string {
+	return backend.now().UTC().Format(time.RFC3339Nano)
+}
+
+func rowKey(partitionKey string, rangeKey string) string {
+	return partitionKey + "\x00" + rangeKey
+}
+
+func statusError(statusCode int, message string) error {
+	return utils.GenericError{StatusCode: statusCode, Message: message}
+}
+
+func notFound(kind string, partitionKey string, rangeKey string) error {
+	return statusError(http.StatusNotFound, fmt.Sprintf("%s not found for PK: %s, RK: %s", kind, partitionKey, rangeKey))
+}
+
+func badRequest(message string) error {
+	return statusError(http.StatusBadRequest, message)
+}
+
+// invalidValueError is a 400 for a request carrying an unknown enum value. It matches both
+// utils.GenericError, like other rejections, and model.ErrInvalidValue, like the real services'
+// client-side check.
+type invalidValueError struct {
+	err error
+}
+
+func (invalid invalidValueError) Error() string {
+	return invalid.err.Error()
+}
+
+func (invalid invalidValueError) Unwrap() []error {
+	return []error{statusError(http.StatusBadRequest, invalid.err.Error()), invalid.err}
+}
+
+// invalidValue wraps a failed Validate, passing nil through.
+func invalidValue(err error) error {
+	if err == nil {
+		return nil
+	}
+	return invalidValueError{err: err}
+}
+
+// once runs create a single time per scope and idempotency key and hands back a copy of the first
+// result for repeats, the way the service answers a retried create. Failed creates are not remembered.
+func once[T any](backend *Backend, scope string, idempotencyKey string, create func() (*T, error)) (*T, error) {
+	if idempotencyKey == "" {
+		return create()
+	}
+	backend.idempotencyMu.Lock()
+	defer backend.idempotencyMu.Unlock()
+	key := scope + "\x00" + idempotencyKey
+	if earlier, ok := backend.idempotent[key]; ok {
+		copied := *earlier.(*T)
+		return &copied, nil
+	}
+	created, err := create()
+	if err != nil {
+		return nil, err
+	}
+	copied := *created
+	backend.idempotent[key] = &copied
+	return created, nil
+}
+
+// keyed is implemented by every stored row so list calls can sort and page them uniformly.
+type keyed interface {
+	keys() (string, string)
+}
+
+// page sorts rows by partition then range key and returns the slice after the paging keys.
+// When only lastRangeKey is given, paging resumes after the row holding that range key; the
+// backend never reuses range keys, so this also works for listings that span partitions.
+func page[R keyed](rows []R, lastPartitionKey *string, lastRangeKey *string, pageSize int) ([]R, *string, *string) {
+	sort.Slice(rows, func(i, j int) bool {
+		leftPK, leftRK := rows[i].keys()
+		rightPK, rightRK := rows[j].keys()
+		if leftPK != rightPK {
+			return leftPK < rightPK
+		}
+		return leftRK < rightRK
+	})
+	start := 0
+	if lastRangeKey != nil && len(*lastRangeKey) > 0 {
+		start = len(rows)
+		for i, row := range rows {
+			partitionKey, rangeKey := row.keys()
+			if lastPartitionKey != nil && len(*lastPartitionKey) > 0 {
+				if partitionKey > *lastPartitionKey || (partitionKey == *lastPartitionKey && rangeKey > *lastRangeKey) {
+					start = i
+					break
+				}
+			} else if rangeKey == *lastRangeKey {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + pageSize
+	if end >= len(rows) {
+		return rows[start:], nil, nil
+	}
+	lastPK, lastRK := rows[end-1].keys()
+	return rows[start:end], &lastPK, &lastRK
+}
+
+// call runs operation against the backend the way a service method would, honouring ctx and
+// injected failures, and shapes the outcome as the real services do.
+func call[T any](ctx context.Context, backend *Backend, methodName string, operation func() (*T, error)) response.Response[T] {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		statusCode := 499
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			statusCode = 504
+		}
+		return response.Response[T]{StatusCode: statusCode, Message: ctxErr.Error(), Error: &ctxErr}
+	}
+	if statusCode, failed := backend.injectedFailure(methodName); failed {
+		var injected error = utils.GenericError{
+			StatusCode: statusCode,
+			Message:    fmt.Sprintf("Error with the request call: %d", statusCode),
+		}
+		return response.Response[T]{StatusCode: statusCode, Message: injected.Error(), Error: &injected}
+	}
+	data, err := operation()
+	if err != nil {
+		var genericError utils.GenericError
+		if errors.As(err, &genericError) {
+			return response.Response[T]{StatusCode: genericError.StatusCode, Message: genericError.Message, Error: &err}
+		}
+		return response.Response[T]{StatusCode: 500, Message: "Internal service error", Error: &err}
+	}
+	return response.Response[T]{Data: data, StatusCode: 200}
+}
+
+// result mirrors the service package's WithError conversion.
+func result[T any](serviceResponse response.Response[T]) (*T, error) {
+	if err := service.ResponseError(serviceResponse); err != nil {
+		return nil, err
+	}
+	return serviceResponse.Data, nil
+}
+
+func success() (*bool, error) {
+	result := true
+	return &result, nil
+}
@@ -0,0 +1,239 @@
+package tickettest
+
+import (
+	json2 "encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nicholaspark09/awsgorocket/utils"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_member_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+	"net/http"
+	"net/http/httptest"
+)
+
+// route maps one controller/action pair onto the backend. methodName is the service method the
+// client uses for the action, so failures injected with Backend.FailNext apply over HTTP too.
+type route struct {
+	methodName string
+	handle     func(backend *Backend, request *http.Request) (any, error)
+}
+
+// Handler speaks the CincinnatiTicketService protocol: every call hits one endpoint with
+// "controller" and "action" query parameters and, for POSTs, a JSON request body.
+type Handler struct {
+	backend *Backend
+	apiKey  string
+	routes  map[string]map[string]route
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler serves backend over HTTP. When apiKey is not empty, requests must carry it in the
+// x-api-key header or they are rejected with 401.
+func NewHandler(backend *Backend, apiKey string) *Handler {
+	return &Handler{backend: backend, apiKey: apiKey, routes: routes()}
+}
+
+// NewServer starts an httptest.Server for backend. Point TicketLibrary at server.URL and Close it
+// when done.
+func NewServer(backend *Backend, apiKey string) *httptest.Server {
+	return httptest.NewServer(NewHandler(backend, apiKey))
+}
+
+func (handler *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if handler.apiKey != "" && request.Header.Get("x-api-key") != handler.apiKey {
+		writeError(writer, http.StatusUnauthorized, "Invalid api key")
+		return
+	}
+	query := request.URL.Query()
+	controller := query.Get("controller")
+	if controller == "" {
+		controller = "tickets"
+	}
+	action := query.Get("action")
+	matched, ok := handler.routes[controller][action]
+	if !ok {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("Unknown controller/action: %s/%s", controller, action))
+		return
+	}
+	if statusCode, failed := handler.backend.injectedFailure(matched.methodName); failed {
+		writeError(writer, statusCode, fmt.Sprintf("Injected failure for %s", matched.methodName))
+		return
+	}
+	data, err := matched.handle(handler.backend, request)
+	if err != nil {
+		var genericError utils.GenericError
+		if errors.As(err, &genericError) {
+			writeError(writer, genericError.StatusCode, genericError.Message)
+			return
+		}
+		writeError(writer, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	_ = json2.NewEncoder(writer).Encode(data)
+}
+
+func writeError(writer http.ResponseWriter, statusCode int, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	_ = json2.NewEncoder(writer).Encode(map[string]string{"message": message})
+}
+
+// post builds a route for a POST action whose body decodes into Request.
+func post[Request any](methodName string, handle func(backend *Backend, body Request) (any, error)) route {
+	return route{
+		methodName: methodName,
+		handle: func(backend *Backend, request *http.Request) (any, error) {
+			if request.Method != http.MethodPost {
+				return nil, statusError(http.StatusMethodNotAllowed, "POST required")
+			}
+			var body Request
+			if err := json2.NewDecoder(request.Body).Decode(&body); err != nil {
+				return nil, badRequest(fmt.Sprintf("Invalid request body: %v", err))
+			}
+			return handle(backend, body)
+		},
+	}
+}
+
+// queryPointer returns nil for missing query parameters, matching the optional paging keys.
+func queryPointer(request *http.Request, name string) *string {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func routes() map[string]map[string]route {
+	return map[string]map[string]route{
+		"tickets": {
+			"create": post("TicketService.CreateAutocut", func(backend *Backend, body ticket_model_request.TicketModelCreateRequest) (any, error) {
+				return backend.createTicket(body)
+			}),
+			"fetch": post("TicketService.Fetch", func(backend *Backend, body model2.FetchRequest) (any, error) {
+				return backend.fetchTicket(body.PartitionKey, body.RangeKey)
+			}),
+			"fetchAll": post("TicketService.FetchAll", func(backend *Backend, body ticket_model_request.TicketModelFetchAllRequest) (any, error) {
+				return backend.fetchAllTickets(body)
+			}),
+			"fetchByUser": post("TicketService.FetchByUser", func(backend *Backend, body ticket_model_request.TicketModelByUserRequest) (any, error) {
+				return backend.fetchTicketsByUser(body)
+			}),
+			"update": post("TicketService.Update", func(backend *Backend, body ticket_model_request.TicketModelUpdateRequest) (any, error) {
+				return backend.updateTicket(body.Ticket)
+			}),
+			"delete": post("TicketService.Delete", func(backend *Backend, body model2.DeleteRequest) (any, error) {
+				return backend.deleteTicket(body)
+			}),
+		},
+		"ticket-comments": {
+			"create": post("TicketCommentService.Create", func(backend *Backend, body ticket_comment_request.TicketCommentModelCreateRequest) (any, error) {
+				return backend.createComment(body)
+			}),
+			"fetchAll": post("TicketCommentService.FetchAll", func(backend *Backend, body ticket_comment_request.TicketCommentModelFetchAllRequest) (any, error) {
+				return backend.fetchAllComments(body)
+			}),
+			"fetch": post("TicketCommentService.Fetch", func(backend *Backend, body model2.FetchRequest) (any, error) {
+				return backend.fetchComment(body.PartitionKey, body.RangeKey)
+			}),
+			"update": post("TicketCommentService.Update", func(backend *Backend, body ticket_comment_request.TicketCommentModelUpdateRequest) (any, error) {
+				return backend.updateComment(body)
+			}),
+			"delete": post("TicketCommentService.Delete", func(backend *Backend, body model2.DeleteRequest) (any, error) {
+				return backend.deleteComment(body)
+			}),
+			"fetchByUser": post("TicketCommentService.FetchByUser", func(backend *Backend, body ticket_comment_request.TicketCommentModelByUserRequest) (any, error) {
+				return backend.fetchCommentsByUser(body)
+			}),
+		},
+		"watchers": {
+			"addWatcher": post("TicketWatchService.AddWatcher", func(backend *Backend, body ticket_watch_request.TicketWatchAddRequest) (any, error) {
+				return backend.addWatcher(body)
+			}),
+			"removeWatcher": post("TicketWatchService.RemoveWatcher", func(backend *Backend, body ticket_watch_request.TicketWatchRemoveRequest) (any, error) {
+				return backend.removeWatcher(body)
+			}),
+			"getUserWatchList": {
+				methodName: "TicketWatchService.GetUserWatchList",
+				handle: func(backend *Backend, request *http.Request) (any, error) {
+					return backend.getUserWatchList(ticket_watch_request.TicketWatchUserListRequest{
+						UserId:       request.URL.Query().Get("userId"),
+						LastRangeKey: queryPointer(request, "lastRangeKey"),
+					}, false)
+				},
+			},
+			"getUserUnreadList": {
+				methodName: "TicketWatchService.GetUserUnreadList",
+				handle: func(backend *Backend, request *http.Request) (any, error) {
+					return backend.getUserWatchList(ticket_watch_request.TicketWatchUserListRequest{
+						UserId:       request.URL.Query().Get("userId"),
+						LastRangeKey: queryPointer(request, "lastRangeKey"),
+					}, true)
+				},
+			},
+			"getTicketWatchers": {
+				methodName: "TicketWatchService.GetTicketWatchers",
+				handle: func(backend *Backend, request *http.Request) (any, error) {
+					query := request.URL.Query()
+					return backend.getTicketWatchers(ticket_watch_request.TicketWatchersListRequest{
+						TicketPartitionKey: query.Get("ticketPK"),
+						TicketRangeKey:     query.Get("ticketRK"),
+						UserId:             query.Get("userId"),
+						LastPartitionKey:   queryPointer(request, "lastPartitionKey"),
+						LastRangeKey:       queryPointer(request, "lastRangeKey"),
+					})
+				},
+			},
+			"markAsRead": post("TicketWatchService.MarkAsRead", func(backend *Backend, body ticket_watch_request.TicketWatchMarkReadRequest) (any, error) {
+				return backend.markAsRead(body)
+			}),
+			"updateWatchEntry": post("TicketWatchService.UpdateWatchEntry", func(backend *Backend, body ticket_watch_request.TicketWatchUpdateRequest) (any, error) {
+				return backend.updateWatchEntry(body)
+			}),
+		},
+		"teams": {
+			"create": post("TicketTeamService.Create", func(backend *Backend, body ticket_team_model_request.TicketTeamModelCreateRequest) (any, error) {
+				return backend.createTeam(body)
+			}),
+			"update": post("TicketTeamService.Update", func(backend *Backend, body ticket_team_model_request.TicketTeamModelUpdateRequest) (any, error) {
+				return backend.updateTeam(body.Team)
+			}),
+			"fetch": post("TicketTeamService.Fetch", func(backend *Backend, body model2.FetchRequest) (any, error) {
+				return backend.fetchTeam(body.PartitionKey, body.RangeKey)
+			}),
+			"fetchAll": post("TicketTeamService.FetchAll", func(backend *Backend, body ticket_team_model_request.TicketTeamModelFetchAllRequest) (any, error) {
+				return backend.fetchAllTeams(body.ClientId, body.LastRangeKey)
+			}),
+			"delete": post("TicketTeamService.Delete", func(backend *Backend, body model2.DeleteRequest) (any, error) {
+				return backend.deleteTeam(body)
+			}),
+		},
+		"teammembers": {
+			"create": post("TicketTeamMemberService.Create", func(backend *Backend, body ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) (any, error) {
+				return backend.createMember(body)
+			}),
+			"update": post("TicketTeamMemberService.Update", func(backend *Backend, body ticket_team_member_model_request.TicketTeamMemberUpdateRequest) (any, error) {
+				return backend.updateMember(body.TeamMember)
+			}),
+			"delete": post("TicketTeamMemberService.Delete", func(backend *Backend, body model2.DeleteRequest) (any, error) {
+				return backend.deleteMember(body)
+			}),
+			"fetchAll": post("TicketTeamMemberService.FetchAll", func(backend *Backend, body ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) (any, error) {
+				return backend.fetchAllMembers(body)
+			}),
+			"fetchByUser": post("TicketTeamMemberService.FetchByUser", func(backend *Backend, body ticket_team_member_model_request.TicketTeamMemberByUserRequest) (any, error) {
+				return backend.fetchMembersByUser(body)
+			}),
+			"fetch": post("TicketTeamMemberService.Fetch", func(backend *Backend, body model2.FetchRequest) (any, error) {
+				return backend.fetchMember(body.PartitionKey, body.RangeKey)
+			}),
+		},
+	}
+}
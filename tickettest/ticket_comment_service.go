@@ -0,0 +1,231 @@
+package tickettest
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_comment_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+)
+
+type commentRow model2.TicketCommentModel
+
+func (row *commentRow) keys() (string, string) {
+	return row.PartitionKey, row.RangeKey
+}
+
+func (row *commentRow) model() *model2.TicketCommentModel {
+	copied := model2.TicketCommentModel(*row)
+	return &copied
+}
+
+// Comments returns a copy of every stored comment ordered by partition and range key.
+func (backend *Backend) Comments() []model2.TicketCommentModel {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rows := make([]*commentRow, 0, len(backend.comments))
+	for _, row := range backend.comments {
+		rows = append(rows, row)
+	}
+	rows, _, _ = page(rows, nil, nil, len(rows)+1)
+	comments := make([]model2.TicketCommentModel, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, *row.model())
+	}
+	return comments
+}
+
+func (backend *Backend) createComment(createRequest ticket_comment_request.TicketCommentModelCreateRequest) (*model2.TicketCommentModel, error) {
+	return once(backend, "comments", createRequest.IdempotencyKey, func() (*model2.TicketCommentModel, error) {
+		return backend.insertComment(createRequest)
+	})
+}
+
+func (backend *Backend) insertComment(createRequest ticket_comment_request.TicketCommentModelCreateRequest) (*model2.TicketCommentModel, error) {
+	if createRequest.UserId == "" {
+		return nil, badRequest("user_id is required")
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if _, ok := backend.tickets[rowKey(createRequest.TicketPartitionKey, createRequest.TicketRangeKey)]; !ok {
+		return nil, notFound("Ticket", createRequest.TicketPartitionKey, createRequest.TicketRangeKey)
+	}
+	now := backend.timestamp()
+	row := &commentRow{
+		PartitionKey: createRequest.TicketPartitionKey + "_" + createRequest.TicketRangeKey,
+		RangeKey:     backend.nextRangeKey(),
+		UserId:       createRequest.UserId,
+		Message:      createRequest.Message,
+		Files:        createRequest.Files,
+		Created:      now,
+		Modified:     now,
+	}
+	backend.comments[rowKey(row.PartitionKey, row.RangeKey)] = row
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchComment(partitionKey string, rangeKey string) (*model2.TicketCommentModel, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row, ok := backend.comments[rowKey(partitionKey, rangeKey)]
+	if !ok {
+		return nil, notFound("Comment", partitionKey, rangeKey)
+	}
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchAllComments(fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) (*model2.TicketCommentModelsResponse, error) {
+	partitionKey := fetchRequest.TicketPartitionKey + "_" + fetchRequest.TicketRangeKey
+	results, _, nextRangeKey := backend.listComments(func(row *commentRow) bool {
+		return row.PartitionKey == partitionKey
+	}, nil, fetchRequest.LastRangeKey)
+	return &model2.TicketCommentModelsResponse{Results: results, LastRangeKey: nextRangeKey}, nil
+}
+
+func (backend *Backend) fetchCommentsByUser(fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) (*model2.TicketCommentModelsResponse, error) {
+	if fetchRequest.UserId == "" {
+		return nil, badRequest("user_id is required")
+	}
+	results, _, nextRangeKey := backend.listComments(func(row *commentRow) bool {
+		return row.UserId == fetchRequest.UserId
+	}, fetchRequest.LastPartitionKey, fetchRequest.LastRangeKey)
+	return &model2.TicketCommentModelsResponse{Results: results, LastRangeKey: nextRangeKey}, nil
+}
+
+func (backend *Backend) listComments(keep func(*commentRow) bool, lastPartitionKey *string, lastRangeKey *string) ([]*model2.TicketCommentModel, *string, *string) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	var rows []*commentRow
+	for _, row := range backend.comments {
+		if keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	rows, nextPartitionKey, nextRangeKey := page(rows, lastPartitionKey, lastRangeKey, backend.pageSize)
+	results := make([]*model2.TicketCommentModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, row.model())
+	}
+	return results, nextPartitionKey, nextRangeKey
+}
+
+func (backend *Backend) updateComment(updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	comment := updateRequest.Comment
+	row, ok := backend.comments[rowKey(comment.PartitionKey, comment.RangeKey)]
+	if !ok {
+		return nil, notFound("Comment", comment.PartitionKey, comment.RangeKey)
+	}
+	row.Message = comment.Message
+	row.Files = comment.Files
+	row.Modified = backend.timestamp()
+	return success()
+}
+
+// deleteComment removes the comment for both soft and hard deletes since comments carry no status.
+func (backend *Backend) deleteComment(deleteRequest model2.DeleteRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	if _, ok := backend.comments[key]; !ok {
+		return nil, notFound("Comment", deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	}
+	delete(backend.comments, key)
+	return success()
+}
+
+// TicketCommentService is an in-memory service.TicketCommentServiceContract backed by a Backend.
+type TicketCommentService struct {
+	backend *Backend
+}
+
+var _ service.TicketCommentServiceContract = (*TicketCommentService)(nil)
+
+func NewTicketCommentService(backend *Backend) *TicketCommentService {
+	return &TicketCommentService{backend: backend}
+}
+
+func (commentService *TicketCommentService) Create(createRequest ticket_comment_request.TicketCommentModelCreateRequest) response.Response[model2.TicketCommentModel] {
+	return commentService.CreateCtx(context.Background(), createRequest)
+}
+
+func (commentService *TicketCommentService) CreateCtx(ctx context.Context, createRequest ticket_comment_request.TicketCommentModelCreateRequest) response.Response[model2.TicketCommentModel] {
+	return call(ctx, commentService.backend, "TicketCommentService.Create", func() (*model2.TicketCommentModel, error) {
+		return commentService.backend.createComment(createRequest)
+	})
+}
+
+func (commentService *TicketCommentService) CreateWithError(ctx context.Context, createRequest ticket_comment_request.TicketCommentModelCreateRequest) (*model2.TicketCommentModel, error) {
+	return result(commentService.CreateCtx(ctx, createRequest))
+}
+
+func (commentService *TicketCommentService) FetchAll(fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) response.Response[model2.TicketCommentModelsResponse] {
+	return commentService.FetchAllCtx(context.Background(), fetchRequest)
+}
+
+func (commentService *TicketCommentService) FetchAllCtx(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) response.Response[model2.TicketCommentModelsResponse] {
+	return call(ctx, commentService.backend, "TicketCommentService.FetchAll", func() (*model2.TicketCommentModelsResponse, error) {
+		return commentService.backend.fetchAllComments(fetchRequest)
+	})
+}
+
+func (commentService *TicketCommentService) FetchAllWithError(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelFetchAllRequest) (*model2.TicketCommentModelsResponse, error) {
+	return result(commentService.FetchAllCtx(ctx, fetchRequest))
+}
+
+func (commentService *TicketCommentService) Fetch(partitionKey string, rangeKey string, userId string) response.Response[model2.TicketCommentModel] {
+	return commentService.FetchCtx(context.Background(), partitionKey, rangeKey, userId)
+}
+
+func (commentService *TicketCommentService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string, userId string) response.Response[model2.TicketCommentModel] {
+	return call(ctx, commentService.backend, "TicketCommentService.Fetch", func() (*model2.TicketCommentModel, error) {
+		return commentService.backend.fetchComment(partitionKey, rangeKey)
+	})
+}
+
+func (commentService *TicketCommentService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string, userId string) (*model2.TicketCommentModel, error) {
+	return result(commentService.FetchCtx(ctx, partitionKey, rangeKey, userId))
+}
+
+func (commentService *TicketCommentService) Update(updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) response.Response[bool] {
+	return commentService.UpdateCtx(context.Background(), updateRequest)
+}
+
+func (commentService *TicketCommentService) UpdateCtx(ctx context.Context, updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) response.Response[bool] {
+	return call(ctx, commentService.backend, "TicketCommentService.Update", func() (*bool, error) {
+		return commentService.backend.updateComment(updateRequest)
+	})
+}
+
+func (commentService *TicketCommentService) UpdateWithError(ctx context.Context, updateRequest ticket_comment_request.TicketCommentModelUpdateRequest) error {
+	return service.ResponseError(commentService.UpdateCtx(ctx, updateRequest))
+}
+
+func (commentService *TicketCommentService) Delete(deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return commentService.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (commentService *TicketCommentService) DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return call(ctx, commentService.backend, "TicketCommentService.Delete", func() (*bool, error) {
+		return commentService.backend.deleteComment(deleteRequest)
+	})
+}
+
+func (commentService *TicketCommentService) DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error {
+	return service.ResponseError(commentService.DeleteCtx(ctx, deleteRequest))
+}
+
+func (commentService *TicketCommentService) FetchByUser(fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) response.Response[model2.TicketCommentModelsResponse] {
+	return commentService.FetchByUserCtx(context.Background(), fetchRequest)
+}
+
+func (commentService *TicketCommentService) FetchByUserCtx(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) response.Response[model2.TicketCommentModelsResponse] {
+	return call(ctx, commentService.backend, "TicketCommentService.FetchByUser", func() (*model2.TicketCommentModelsResponse, error) {
+		return commentService.backend.fetchCommentsByUser(fetchRequest)
+	})
+}
+
+func (commentService *TicketCommentService) FetchByUserWithError(ctx context.Context, fetchRequest ticket_comment_request.TicketCommentModelByUserRequest) (*model2.TicketCommentModelsResponse, error) {
+	return result(commentService.FetchByUserCtx(ctx, fetchRequest))
+}
@@ -0,0 +1,20 @@
+package tickettest
+
+import (
+	"github.com/nicholaspark09/cincinnatiticketlibrary/ticket_library"
+)
+
+// NewTicketLibrary wires every in-memory service to backend and returns a ready TicketLibrary.
+// Library-level options such as ticket_library.WithAsyncAutocut apply as usual.
+func NewTicketLibrary(backend *Backend, clientId string, teamId string, autoCutKey string, opts ...ticket_library.Option) ticket_library.TicketLibrary {
+	return ticket_library.ProvideTicketLibraryWithServices(
+		clientId,
+		teamId,
+		NewTicketService(backend, clientId, teamId, autoCutKey),
+		NewTicketCommentService(backend),
+		NewTicketWatchService(backend),
+		NewTicketTeamService(backend),
+		NewTicketTeamMemberService(backend),
+		opts...,
+	)
+}
@@ -0,0 +1,318 @@
+package tickettest
+
+import (
+	"context"
+	"errors"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+)
+
+type ticketRow model.TicketModel
+
+func (row *ticketRow) keys() (string, string) {
+	return row.PartitionKey, row.RangeKey
+}
+
+func (row *ticketRow) model() *model.TicketModel {
+	copied := model.TicketModel(*row)
+	return &copied
+}
+
+// PutTicket stores ticket as-is, replacing any ticket with the same keys.
+func (backend *Backend) PutTicket(ticket model.TicketModel) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row := ticketRow(ticket)
+	backend.tickets[rowKey(ticket.PartitionKey, ticket.RangeKey)] = &row
+}
+
+// Tickets returns a copy of every stored ticket ordered by partition and range key.
+func (backend *Backend) Tickets() []model.TicketModel {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rows := make([]*ticketRow, 0, len(backend.tickets))
+	for _, row := range backend.tickets {
+		rows = append(rows, row)
+	}
+	rows, _, _ = page(rows, nil, nil, len(rows)+1)
+	tickets := make([]model.TicketModel, 0, len(rows))
+	for _, row := range rows {
+		tickets = append(tickets, *row.model())
+	}
+	return tickets
+}
+
+func (backend *Backend) createTicket(createRequest ticket_model_request.TicketModelCreateRequest) (*model.TicketModel, error) {
+	return once(backend, "tickets", createRequest.IdempotencyKey, func() (*model.TicketModel, error) {
+		return backend.insertTicket(createRequest)
+	})
+}
+
+func (backend *Backend) insertTicket(createRequest ticket_model_request.TicketModelCreateRequest) (*model.TicketModel, error) {
+	if createRequest.ClientId == "" || createRequest.TeamRangeKey == "" {
+		return nil, badRequest("client_id and team_range_key are required")
+	}
+	if createRequest.Title == "" {
+		return nil, badRequest("title is required")
+	}
+	if err := errors.Join(model.TicketStatus(createRequest.Status).Validate(), model.Severity(createRequest.Severity).Validate()); err != nil {
+		return nil, invalidValue(err)
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	now := backend.timestamp()
+	row := &ticketRow{
+		PartitionKey: createRequest.ClientId + "_" + createRequest.TeamRangeKey,
+		RangeKey:     backend.nextRangeKey(),
+		Title:        createRequest.Title,
+		Description:  createRequest.Description,
+		Files:        createRequest.Files,
+		Severity:     createRequest.Severity,
+		Status:       createRequest.Status,
+		UserId:       createRequest.UserId,
+		TraceId:      createRequest.TraceId,
+		Created:      now,
+		Modified:     now,
+	}
+	backend.tickets[rowKey(row.PartitionKey, row.RangeKey)] = row
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchTicket(partitionKey string, rangeKey string) (*model.TicketModel, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row, ok := backend.tickets[rowKey(partitionKey, rangeKey)]
+	if !ok {
+		return nil, notFound("Ticket", partitionKey, rangeKey)
+	}
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchAllTickets(fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) (*model.TicketModelsResponse, error) {
+	partitionKey := fetchAllRequest.ClientId + "_" + fetchAllRequest.TeamId
+	return backend.listTickets(func(row *ticketRow) bool {
+		return row.PartitionKey == partitionKey
+	}, nil, fetchAllRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) fetchTicketsByUser(fetchRequest ticket_model_request.TicketModelByUserRequest) (*model.TicketModelsResponse, error) {
+	if fetchRequest.UserId == "" {
+		return nil, badRequest("user_id is required")
+	}
+	return backend.listTickets(func(row *ticketRow) bool {
+		return row.UserId == fetchRequest.UserId
+	}, fetchRequest.LastPartitionKey, fetchRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) listTickets(keep func(*ticketRow) bool, lastPartitionKey *string, lastRangeKey *string) *model.TicketModelsResponse {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	var rows []*ticketRow
+	for _, row := range backend.tickets {
+		if keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	rows, nextPartitionKey, nextRangeKey := page(rows, lastPartitionKey, lastRangeKey, backend.pageSize)
+	results := make([]*model.TicketModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, row.model())
+	}
+	return &model.TicketModelsResponse{Results: results, LastPartitionKey: nextPartitionKey, LastRangeKey: nextRangeKey}
+}
+
+func (backend *Backend) updateTicket(ticketModel model.TicketModel) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(ticketModel.PartitionKey, ticketModel.RangeKey)
+	existing, ok := backend.tickets[key]
+	if !ok {
+		return nil, notFound("Ticket", ticketModel.PartitionKey, ticketModel.RangeKey)
+	}
+	row := ticketRow(ticketModel)
+	row.Created = existing.Created
+	row.Modified = backend.timestamp()
+	backend.tickets[key] = &row
+	return success()
+}
+
+func (backend *Backend) deleteTicket(deleteRequest model.DeleteRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	row, ok := backend.tickets[key]
+	if !ok {
+		return nil, notFound("Ticket", deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	}
+	if deleteRequest.IsHardDelete {
+		delete(backend.tickets, key)
+	} else {
+		row.Status = deletedStatus
+		row.Modified = backend.timestamp()
+	}
+	return success()
+}
+
+// deletedStatus is what a soft delete leaves in the Status field of tickets, teams and members.
+const deletedStatus = "DELETED"
+
+// TicketService is an in-memory service.TicketServiceContract backed by a Backend.
+type TicketService struct {
+	backend    *Backend
+	clientId   string
+	teamId     string
+	autoCutKey string
+	// transitioner runs Transition through the calls the real service makes, so failures are injected
+	// per call and a concurrent write can land between them.
+	transitioner *service.TicketTransitioner
+}
+
+var _ service.TicketServiceContract = (*TicketService)(nil)
+
+func NewTicketService(backend *Backend, clientId string, teamId string, autoCutKey string) *TicketService {
+	return &TicketService{
+		backend:      backend,
+		clientId:     clientId,
+		teamId:       teamId,
+		autoCutKey:   autoCutKey,
+		transitioner: service.NewTicketTransitioner(nil, 0, nil),
+	}
+}
+
+// SetTransitions replaces the state machine Transition enforces, like service.WithTicketTransitions.
+func (ticketService *TicketService) SetTransitions(transitions service.TicketTransitions) {
+	ticketService.transitioner = service.NewTicketTransitioner(transitions, 0, nil)
+}
+
+func (ticketService *TicketService) CreateAutocut(title string, description string, files string, severity int) bool {
+	return ticketService.CreateAutocutCtx(context.Background(), title, description, files, severity)
+}
+
+func (ticketService *TicketService) CreateAutocutCtx(ctx context.Context, title string, description string, files string, severity int) bool {
+	_, err := ticketService.CreateAutocutWithError(ctx, title, description, files, severity)
+	return err == nil
+}
+
+func (ticketService *TicketService) CreateAutocutWithError(ctx context.Context, title string, description string, files string, severity int) (*model.TicketModel, error) {
+	createResponse := call(ctx, ticketService.backend, "TicketService.CreateAutocut", func() (*model.TicketModel, error) {
+		createRequest := ticket_model_request.TicketModelCreateRequest{
+			ClientId:     ticketService.clientId,
+			TeamRangeKey: ticketService.teamId,
+			Title:        title,
+			Description:  description,
+			Files:        files,
+			Severity:     severity,
+			UserId:       ticketService.autoCutKey,
+			Status:       string(model.TicketStatusOpen),
+		}
+		service.LinkTrace(ctx, &createRequest)
+		return ticketService.backend.createTicket(createRequest)
+	})
+	return result(createResponse)
+}
+
+func (ticketService *TicketService) Fetch(partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	return ticketService.FetchCtx(context.Background(), partitionKey, rangeKey)
+}
+
+func (ticketService *TicketService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string) response.Response[model.TicketModel] {
+	return call(ctx, ticketService.backend, "TicketService.Fetch", func() (*model.TicketModel, error) {
+		return ticketService.backend.fetchTicket(partitionKey, rangeKey)
+	})
+}
+
+func (ticketService *TicketService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string) (*model.TicketModel, error) {
+	return result(ticketService.FetchCtx(ctx, partitionKey, rangeKey))
+}
+
+func (ticketService *TicketService) FetchMany(keys []service.TicketKey) service.FetchManyResult {
+	return ticketService.FetchManyCtx(context.Background(), keys)
+}
+
+// FetchManyCtx goes through FetchWithError per key, so failures injected for "TicketService.Fetch"
+// hit individual keys.
+func (ticketService *TicketService) FetchManyCtx(ctx context.Context, keys []service.TicketKey) service.FetchManyResult {
+	return service.FetchTickets(ctx, keys, service.DefaultFetchConcurrency, ticketService.FetchWithError)
+}
+
+func (ticketService *TicketService) FetchAll(fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse] {
+	return ticketService.FetchAllCtx(context.Background(), fetchAllRequest)
+}
+
+func (ticketService *TicketService) FetchAllCtx(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) response.Response[model.TicketModelsResponse] {
+	return call(ctx, ticketService.backend, "TicketService.FetchAll", func() (*model.TicketModelsResponse, error) {
+		return ticketService.backend.fetchAllTickets(fetchAllRequest)
+	})
+}
+
+func (ticketService *TicketService) FetchAllWithError(ctx context.Context, fetchAllRequest ticket_model_request.TicketModelFetchAllRequest) (*model.TicketModelsResponse, error) {
+	return result(ticketService.FetchAllCtx(ctx, fetchAllRequest))
+}
+
+func (ticketService *TicketService) FetchByUser(fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse] {
+	return ticketService.FetchByUserCtx(context.Background(), fetchRequest)
+}
+
+func (ticketService *TicketService) FetchByUserCtx(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) response.Response[model.TicketModelsResponse] {
+	return call(ctx, ticketService.backend, "TicketService.FetchByUser", func() (*model.TicketModelsResponse, error) {
+		return ticketService.backend.fetchTicketsByUser(fetchRequest)
+	})
+}
+
+func (ticketService *TicketService) FetchByUserWithError(ctx context.Context, fetchRequest ticket_model_request.TicketModelByUserRequest) (*model.TicketModelsResponse, error) {
+	return result(ticketService.FetchByUserCtx(ctx, fetchRequest))
+}
+
+func (ticketService *TicketService) Update(userId string, ticketModel model.TicketModel) response.Response[bool] {
+	return ticketService.UpdateCtx(context.Background(), userId, ticketModel)
+}
+
+func (ticketService *TicketService) UpdateCtx(ctx context.Context, userId string, ticketModel model.TicketModel) response.Response[bool] {
+	return call(ctx, ticketService.backend, "TicketService.Update", func() (*bool, error) {
+		return ticketService.backend.updateTicket(ticketModel)
+	})
+}
+
+func (ticketService *TicketService) UpdateWithError(ctx context.Context, userId string, ticketModel model.TicketModel) error {
+	return service.ResponseError(ticketService.UpdateCtx(ctx, userId, ticketModel))
+}
+
+func (ticketService *TicketService) Delete(deleteRequest model.DeleteRequest) response.Response[bool] {
+	return ticketService.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (ticketService *TicketService) DeleteCtx(ctx context.Context, deleteRequest model.DeleteRequest) response.Response[bool] {
+	return call(ctx, ticketService.backend, "TicketService.Delete", func() (*bool, error) {
+		return ticketService.backend.deleteTicket(deleteRequest)
+	})
+}
+
+func (ticketService *TicketService) DeleteWithError(ctx context.Context, deleteRequest model.DeleteRequest) error {
+	return service.ResponseError(ticketService.DeleteCtx(ctx, deleteRequest))
+}
+
+func (ticketService *TicketService) Transition(ticketKey service.TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	return ticketService.TransitionCtx(context.Background(), ticketKey, toStatus, actor, reason)
+}
+
+func (ticketService *TicketService) TransitionCtx(ctx context.Context, ticketKey service.TicketKey, toStatus model.TicketStatus, actor string, reason string) bool {
+	_, err := ticketService.TransitionWithError(ctx, ticketKey, toStatus, actor, reason)
+	return err == nil
+}
+
+// TransitionWithError fetches, updates and refreshes the watch entries through this backend the way
+// service.TicketService does, so failures are injected for "TicketService.Fetch",
+// "TicketService.Update", "TicketWatchService.GetTicketWatchers" and
+// "TicketWatchService.UpdateWatchEntry".
+func (ticketService *TicketService) TransitionWithError(
+	ctx context.Context,
+	ticketKey service.TicketKey,
+	toStatus model.TicketStatus,
+	actor string,
+	reason string,
+) (*model.TicketModel, error) {
+	watchService := NewTicketWatchService(ticketService.backend)
+	return ticketService.transitioner.Transition(ctx, ticketService, watchService, ticketKey, toStatus, actor, reason)
+}
@@ -0,0 +1,248 @@
+package tickettest
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_member_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+	"strings"
+)
+
+type memberRow model2.TicketTeamMemberModel
+
+func (row *memberRow) keys() (string, string) {
+	return row.PartitionKey, row.RangeKey
+}
+
+func (row *memberRow) model() *model2.TicketTeamMemberModel {
+	copied := model2.TicketTeamMemberModel(*row)
+	return &copied
+}
+
+// Members returns a copy of every stored team member ordered by partition and range key.
+func (backend *Backend) Members() []model2.TicketTeamMemberModel {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rows := make([]*memberRow, 0, len(backend.members))
+	for _, row := range backend.members {
+		rows = append(rows, row)
+	}
+	rows, _, _ = page(rows, nil, nil, len(rows)+1)
+	members := make([]model2.TicketTeamMemberModel, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, *row.model())
+	}
+	return members
+}
+
+// obfuscateEmail keeps the first character of the local part and the domain, e.g. j***@example.com.
+func obfuscateEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+func (backend *Backend) createMember(createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) (*model2.TicketTeamMemberModel, error) {
+	return once(backend, "members", createRequest.IdempotencyKey, func() (*model2.TicketTeamMemberModel, error) {
+		return backend.insertMember(createRequest)
+	})
+}
+
+func (backend *Backend) insertMember(createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) (*model2.TicketTeamMemberModel, error) {
+	if createRequest.ClientId == "" || createRequest.TicketTeamId == "" {
+		return nil, badRequest("client_id and ticket_team_id are required")
+	}
+	if err := model2.MemberLevel(createRequest.Level).Validate(); err != nil {
+		return nil, invalidValue(err)
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	now := backend.timestamp()
+	row := &memberRow{
+		PartitionKey:    createRequest.ClientId + "_" + createRequest.TicketTeamId,
+		RangeKey:        backend.nextRangeKey(),
+		Title:           createRequest.Title,
+		Description:     createRequest.Description,
+		Status:          createRequest.Status,
+		ObfuscatedEmail: obfuscateEmail(createRequest.Email),
+		UserId:          createRequest.UserId,
+		Level:           createRequest.Level,
+		Created:         now,
+		Modified:        now,
+	}
+	backend.members[rowKey(row.PartitionKey, row.RangeKey)] = row
+	return row.model(), nil
+}
+
+func (backend *Backend) updateMember(memberModel model2.TicketTeamMemberModel) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(memberModel.PartitionKey, memberModel.RangeKey)
+	existing, ok := backend.members[key]
+	if !ok {
+		return nil, notFound("TeamMember", memberModel.PartitionKey, memberModel.RangeKey)
+	}
+	row := memberRow(memberModel)
+	row.Created = existing.Created
+	row.Modified = backend.timestamp()
+	backend.members[key] = &row
+	return success()
+}
+
+func (backend *Backend) deleteMember(deleteRequest model2.DeleteRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	row, ok := backend.members[key]
+	if !ok {
+		return nil, notFound("TeamMember", deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	}
+	if deleteRequest.IsHardDelete {
+		delete(backend.members, key)
+	} else {
+		row.Status = deletedStatus
+		row.Modified = backend.timestamp()
+	}
+	return success()
+}
+
+func (backend *Backend) fetchMember(partitionKey string, rangeKey string) (*model2.TicketTeamMemberModel, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row, ok := backend.members[rowKey(partitionKey, rangeKey)]
+	if !ok {
+		return nil, notFound("TeamMember", partitionKey, rangeKey)
+	}
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchAllMembers(fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) (*model2.TicketTeamMemberModelsResponse, error) {
+	partitionKey := fetchAllRequest.ClientId + "_" + fetchAllRequest.TicketTeamId
+	return backend.listMembers(func(row *memberRow) bool {
+		return row.PartitionKey == partitionKey
+	}, nil, fetchAllRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) fetchMembersByUser(fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) (*model2.TicketTeamMemberModelsResponse, error) {
+	if fetchRequest.UserId == "" {
+		return nil, badRequest("user_id is required")
+	}
+	return backend.listMembers(func(row *memberRow) bool {
+		return row.UserId == fetchRequest.UserId
+	}, fetchRequest.LastPartitionKey, fetchRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) listMembers(keep func(*memberRow) bool, lastPartitionKey *string, lastRangeKey *string) *model2.TicketTeamMemberModelsResponse {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	var rows []*memberRow
+	for _, row := range backend.members {
+		if keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	rows, nextPartitionKey, nextRangeKey := page(rows, lastPartitionKey, lastRangeKey, backend.pageSize)
+	results := make([]*model2.TicketTeamMemberModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, row.model())
+	}
+	return &model2.TicketTeamMemberModelsResponse{Results: results, LastPartitionKey: nextPartitionKey, LastRangeKey: nextRangeKey}
+}
+
+// TicketTeamMemberService is an in-memory service.TicketTeamMemberServiceContract backed by a Backend.
+type TicketTeamMemberService struct {
+	backend *Backend
+}
+
+var _ service.TicketTeamMemberServiceContract = (*TicketTeamMemberService)(nil)
+
+func NewTicketTeamMemberService(backend *Backend) *TicketTeamMemberService {
+	return &TicketTeamMemberService{backend: backend}
+}
+
+func (memberService *TicketTeamMemberService) Create(createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) response.Response[model2.TicketTeamMemberModel] {
+	return memberService.CreateCtx(context.Background(), createRequest)
+}
+
+func (memberService *TicketTeamMemberService) CreateCtx(ctx context.Context, createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) response.Response[model2.TicketTeamMemberModel] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.Create", func() (*model2.TicketTeamMemberModel, error) {
+		return memberService.backend.createMember(createRequest)
+	})
+}
+
+func (memberService *TicketTeamMemberService) CreateWithError(ctx context.Context, createRequest ticket_team_member_model_request.TicketTeamMemberModelCreateRequest) (*model2.TicketTeamMemberModel, error) {
+	return result(memberService.CreateCtx(ctx, createRequest))
+}
+
+func (memberService *TicketTeamMemberService) Update(userId string, memberModel model2.TicketTeamMemberModel) response.Response[bool] {
+	return memberService.UpdateCtx(context.Background(), userId, memberModel)
+}
+
+func (memberService *TicketTeamMemberService) UpdateCtx(ctx context.Context, userId string, memberModel model2.TicketTeamMemberModel) response.Response[bool] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.Update", func() (*bool, error) {
+		return memberService.backend.updateMember(memberModel)
+	})
+}
+
+func (memberService *TicketTeamMemberService) UpdateWithError(ctx context.Context, userId string, memberModel model2.TicketTeamMemberModel) error {
+	return service.ResponseError(memberService.UpdateCtx(ctx, userId, memberModel))
+}
+
+func (memberService *TicketTeamMemberService) Delete(deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return memberService.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (memberService *TicketTeamMemberService) DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.Delete", func() (*bool, error) {
+		return memberService.backend.deleteMember(deleteRequest)
+	})
+}
+
+func (memberService *TicketTeamMemberService) DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error {
+	return service.ResponseError(memberService.DeleteCtx(ctx, deleteRequest))
+}
+
+func (memberService *TicketTeamMemberService) FetchAll(fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) response.Response[model2.TicketTeamMemberModelsResponse] {
+	return memberService.FetchAllCtx(context.Background(), fetchAllRequest)
+}
+
+func (memberService *TicketTeamMemberService) FetchAllCtx(ctx context.Context, fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) response.Response[model2.TicketTeamMemberModelsResponse] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.FetchAll", func() (*model2.TicketTeamMemberModelsResponse, error) {
+		return memberService.backend.fetchAllMembers(fetchAllRequest)
+	})
+}
+
+func (memberService *TicketTeamMemberService) FetchAllWithError(ctx context.Context, fetchAllRequest ticket_team_member_model_request.TicketTeamMemberModelFetchAllRequest) (*model2.TicketTeamMemberModelsResponse, error) {
+	return result(memberService.FetchAllCtx(ctx, fetchAllRequest))
+}
+
+func (memberService *TicketTeamMemberService) FetchByUser(fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) response.Response[model2.TicketTeamMemberModelsResponse] {
+	return memberService.FetchByUserCtx(context.Background(), fetchRequest)
+}
+
+func (memberService *TicketTeamMemberService) FetchByUserCtx(ctx context.Context, fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) response.Response[model2.TicketTeamMemberModelsResponse] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.FetchByUser", func() (*model2.TicketTeamMemberModelsResponse, error) {
+		return memberService.backend.fetchMembersByUser(fetchRequest)
+	})
+}
+
+func (memberService *TicketTeamMemberService) FetchByUserWithError(ctx context.Context, fetchRequest ticket_team_member_model_request.TicketTeamMemberByUserRequest) (*model2.TicketTeamMemberModelsResponse, error) {
+	return result(memberService.FetchByUserCtx(ctx, fetchRequest))
+}
+
+func (memberService *TicketTeamMemberService) Fetch(email string, partitionKey string, rangeKey string) response.Response[model2.TicketTeamMemberModel] {
+	return memberService.FetchCtx(context.Background(), email, partitionKey, rangeKey)
+}
+
+func (memberService *TicketTeamMemberService) FetchCtx(ctx context.Context, email string, partitionKey string, rangeKey string) response.Response[model2.TicketTeamMemberModel] {
+	return call(ctx, memberService.backend, "TicketTeamMemberService.Fetch", func() (*model2.TicketTeamMemberModel, error) {
+		return memberService.backend.fetchMember(partitionKey, rangeKey)
+	})
+}
+
+func (memberService *TicketTeamMemberService) FetchWithError(ctx context.Context, email string, partitionKey string, rangeKey string) (*model2.TicketTeamMemberModel, error) {
+	return result(memberService.FetchCtx(ctx, email, partitionKey, rangeKey))
+}
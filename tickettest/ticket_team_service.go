@@ -0,0 +1,204 @@
+package tickettest
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_team_model_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+)
+
+type teamRow model2.TicketTeamModel
+
+func (row *teamRow) keys() (string, string) {
+	return row.PartitionKey, row.RangeKey
+}
+
+func (row *teamRow) model() *model2.TicketTeamModel {
+	copied := model2.TicketTeamModel(*row)
+	return &copied
+}
+
+// Teams returns a copy of every stored team ordered by partition and range key.
+func (backend *Backend) Teams() []model2.TicketTeamModel {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rows := make([]*teamRow, 0, len(backend.teams))
+	for _, row := range backend.teams {
+		rows = append(rows, row)
+	}
+	rows, _, _ = page(rows, nil, nil, len(rows)+1)
+	teams := make([]model2.TicketTeamModel, 0, len(rows))
+	for _, row := range rows {
+		teams = append(teams, *row.model())
+	}
+	return teams
+}
+
+func (backend *Backend) createTeam(createRequest ticket_team_model_request.TicketTeamModelCreateRequest) (*model2.TicketTeamModel, error) {
+	return once(backend, "teams", createRequest.IdempotencyKey, func() (*model2.TicketTeamModel, error) {
+		return backend.insertTeam(createRequest)
+	})
+}
+
+func (backend *Backend) insertTeam(createRequest ticket_team_model_request.TicketTeamModelCreateRequest) (*model2.TicketTeamModel, error) {
+	if createRequest.ClientId == "" || createRequest.Title == "" {
+		return nil, badRequest("client_id and title are required")
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	now := backend.timestamp()
+	row := &teamRow{
+		PartitionKey: createRequest.ClientId,
+		RangeKey:     backend.nextRangeKey(),
+		Title:        createRequest.Title,
+		Description:  createRequest.Description,
+		UserId:       createRequest.UserId,
+		Category:     createRequest.Category,
+		Status:       createRequest.Status,
+		Created:      now,
+		Modified:     now,
+	}
+	backend.teams[rowKey(row.PartitionKey, row.RangeKey)] = row
+	return row.model(), nil
+}
+
+func (backend *Backend) updateTeam(teamModel model2.TicketTeamModel) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(teamModel.PartitionKey, teamModel.RangeKey)
+	existing, ok := backend.teams[key]
+	if !ok {
+		return nil, notFound("Team", teamModel.PartitionKey, teamModel.RangeKey)
+	}
+	row := teamRow(teamModel)
+	row.Created = existing.Created
+	row.Modified = backend.timestamp()
+	backend.teams[key] = &row
+	return success()
+}
+
+func (backend *Backend) fetchTeam(partitionKey string, rangeKey string) (*model2.TicketTeamModel, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row, ok := backend.teams[rowKey(partitionKey, rangeKey)]
+	if !ok {
+		return nil, notFound("Team", partitionKey, rangeKey)
+	}
+	return row.model(), nil
+}
+
+func (backend *Backend) fetchAllTeams(clientId string, lastRangeKey *string) (*model2.TicketTeamModelsResponse, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	var rows []*teamRow
+	for _, row := range backend.teams {
+		if row.PartitionKey == clientId {
+			rows = append(rows, row)
+		}
+	}
+	rows, nextPartitionKey, nextRangeKey := page(rows, nil, lastRangeKey, backend.pageSize)
+	results := make([]*model2.TicketTeamModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, row.model())
+	}
+	return &model2.TicketTeamModelsResponse{Results: results, LastPartitionKey: nextPartitionKey, LastRangeKey: nextRangeKey}, nil
+}
+
+func (backend *Backend) deleteTeam(deleteRequest model2.DeleteRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	row, ok := backend.teams[key]
+	if !ok {
+		return nil, notFound("Team", deleteRequest.PartitionKey, deleteRequest.RangeKey)
+	}
+	if deleteRequest.IsHardDelete {
+		delete(backend.teams, key)
+	} else {
+		row.Status = deletedStatus
+		row.Modified = backend.timestamp()
+	}
+	return success()
+}
+
+// TicketTeamService is an in-memory service.TicketTeamServiceContract backed by a Backend.
+type TicketTeamService struct {
+	backend *Backend
+}
+
+var _ service.TicketTeamServiceContract = (*TicketTeamService)(nil)
+
+func NewTicketTeamService(backend *Backend) *TicketTeamService {
+	return &TicketTeamService{backend: backend}
+}
+
+func (teamService *TicketTeamService) Create(createRequest ticket_team_model_request.TicketTeamModelCreateRequest) response.Response[model2.TicketTeamModel] {
+	return teamService.CreateCtx(context.Background(), createRequest)
+}
+
+func (teamService *TicketTeamService) CreateCtx(ctx context.Context, createRequest ticket_team_model_request.TicketTeamModelCreateRequest) response.Response[model2.TicketTeamModel] {
+	return call(ctx, teamService.backend, "TicketTeamService.Create", func() (*model2.TicketTeamModel, error) {
+		return teamService.backend.createTeam(createRequest)
+	})
+}
+
+func (teamService *TicketTeamService) CreateWithError(ctx context.Context, createRequest ticket_team_model_request.TicketTeamModelCreateRequest) (*model2.TicketTeamModel, error) {
+	return result(teamService.CreateCtx(ctx, createRequest))
+}
+
+func (teamService *TicketTeamService) Update(userId string, teamModel model2.TicketTeamModel) response.Response[bool] {
+	return teamService.UpdateCtx(context.Background(), userId, teamModel)
+}
+
+func (teamService *TicketTeamService) UpdateCtx(ctx context.Context, userId string, teamModel model2.TicketTeamModel) response.Response[bool] {
+	return call(ctx, teamService.backend, "TicketTeamService.Update", func() (*bool, error) {
+		return teamService.backend.updateTeam(teamModel)
+	})
+}
+
+func (teamService *TicketTeamService) UpdateWithError(ctx context.Context, userId string, teamModel model2.TicketTeamModel) error {
+	return service.ResponseError(teamService.UpdateCtx(ctx, userId, teamModel))
+}
+
+func (teamService *TicketTeamService) Fetch(partitionKey string, rangeKey string, userId string) response.Response[model2.TicketTeamModel] {
+	return teamService.FetchCtx(context.Background(), partitionKey, rangeKey, userId)
+}
+
+func (teamService *TicketTeamService) FetchCtx(ctx context.Context, partitionKey string, rangeKey string, userId string) response.Response[model2.TicketTeamModel] {
+	return call(ctx, teamService.backend, "TicketTeamService.Fetch", func() (*model2.TicketTeamModel, error) {
+		return teamService.backend.fetchTeam(partitionKey, rangeKey)
+	})
+}
+
+func (teamService *TicketTeamService) FetchWithError(ctx context.Context, partitionKey string, rangeKey string, userId string) (*model2.TicketTeamModel, error) {
+	return result(teamService.FetchCtx(ctx, partitionKey, rangeKey, userId))
+}
+
+func (teamService *TicketTeamService) FetchAll(clientId string, lastRangeKey *string) response.Response[model2.TicketTeamModelsResponse] {
+	return teamService.FetchAllCtx(context.Background(), clientId, lastRangeKey)
+}
+
+func (teamService *TicketTeamService) FetchAllCtx(ctx context.Context, clientId string, lastRangeKey *string) response.Response[model2.TicketTeamModelsResponse] {
+	return call(ctx, teamService.backend, "TicketTeamService.FetchAll", func() (*model2.TicketTeamModelsResponse, error) {
+		return teamService.backend.fetchAllTeams(clientId, lastRangeKey)
+	})
+}
+
+func (teamService *TicketTeamService) FetchAllWithError(ctx context.Context, clientId string, lastRangeKey *string) (*model2.TicketTeamModelsResponse, error) {
+	return result(teamService.FetchAllCtx(ctx, clientId, lastRangeKey))
+}
+
+func (teamService *TicketTeamService) Delete(deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return teamService.DeleteCtx(context.Background(), deleteRequest)
+}
+
+func (teamService *TicketTeamService) DeleteCtx(ctx context.Context, deleteRequest model2.DeleteRequest) response.Response[bool] {
+	return call(ctx, teamService.backend, "TicketTeamService.Delete", func() (*bool, error) {
+		return teamService.backend.deleteTeam(deleteRequest)
+	})
+}
+
+func (teamService *TicketTeamService) DeleteWithError(ctx context.Context, deleteRequest model2.DeleteRequest) error {
+	return service.ResponseError(teamService.DeleteCtx(ctx, deleteRequest))
+}
@@ -0,0 +1,281 @@
+package tickettest
+
+import (
+	"context"
+	response "github.com/nicholaspark09/awsgorocket/model"
+	model2 "github.com/nicholaspark09/cincinnatiticketlibrary/model"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/model/ticket_watch_request"
+	"github.com/nicholaspark09/cincinnatiticketlibrary/service"
+)
+
+type watchRow model2.TicketWatchModel
+
+func (row *watchRow) keys() (string, string) {
+	return row.PartitionKey, row.RangeKey
+}
+
+func (row *watchRow) model() *model2.TicketWatchModel {
+	copied := model2.TicketWatchModel(*row)
+	return &copied
+}
+
+// PutWatch stores watch as-is, replacing any entry with the same keys.
+func (backend *Backend) PutWatch(watch model2.TicketWatchModel) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	row := watchRow(watch)
+	backend.watches[rowKey(watch.PartitionKey, watch.RangeKey)] = &row
+}
+
+// Watches returns a copy of every stored watch entry ordered by partition and range key.
+func (backend *Backend) Watches() []model2.TicketWatchModel {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rows := make([]*watchRow, 0, len(backend.watches))
+	for _, row := range backend.watches {
+		rows = append(rows, row)
+	}
+	rows, _, _ = page(rows, nil, nil, len(rows)+1)
+	watches := make([]model2.TicketWatchModel, 0, len(rows))
+	for _, row := range rows {
+		watches = append(watches, *row.model())
+	}
+	return watches
+}
+
+// watchRangeKey is the "{TicketPK}_{TicketRK}" range key of a watch entry.
+func watchRangeKey(ticketPartitionKey string, ticketRangeKey string) string {
+	return ticketPartitionKey + "_" + ticketRangeKey
+}
+
+func (backend *Backend) addWatcher(addRequest ticket_watch_request.TicketWatchAddRequest) (*model2.TicketWatchModel, error) {
+	return once(backend, "watches", addRequest.IdempotencyKey, func() (*model2.TicketWatchModel, error) {
+		return backend.upsertWatcher(addRequest)
+	})
+}
+
+func (backend *Backend) upsertWatcher(addRequest ticket_watch_request.TicketWatchAddRequest) (*model2.TicketWatchModel, error) {
+	if addRequest.UserId == "" {
+		return nil, badRequest("user_id is required")
+	}
+	if err := model2.WatchRole(addRequest.Role).Validate(); err != nil {
+		return nil, invalidValue(err)
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	ticket, ok := backend.tickets[rowKey(addRequest.TicketPartitionKey, addRequest.TicketRangeKey)]
+	if !ok {
+		return nil, notFound("Ticket", addRequest.TicketPartitionKey, addRequest.TicketRangeKey)
+	}
+	key := rowKey(addRequest.UserId, watchRangeKey(addRequest.TicketPartitionKey, addRequest.TicketRangeKey))
+	now := backend.timestamp()
+	if existing, ok := backend.watches[key]; ok {
+		existing.Role = addRequest.Role
+		existing.Modified = now
+		return existing.model(), nil
+	}
+	row := &watchRow{
+		PartitionKey:  addRequest.UserId,
+		RangeKey:      watchRangeKey(addRequest.TicketPartitionKey, addRequest.TicketRangeKey),
+		Role:          addRequest.Role,
+		TicketTitle:   ticket.Title,
+		TicketStatus:  ticket.Status,
+		LastUpdated:   ticket.Modified,
+		WatchingSince: now,
+		Created:       now,
+		Modified:      now,
+	}
+	backend.watches[key] = row
+	return row.model(), nil
+}
+
+// removeWatcher accepts the ticket keys in PartitionKey/RangeKey, falling back to the watch
+// entry's own keys when those are what the caller sent.
+func (backend *Backend) removeWatcher(removeRequest ticket_watch_request.TicketWatchRemoveRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	key := rowKey(removeRequest.UserId, watchRangeKey(removeRequest.PartitionKey, removeRequest.RangeKey))
+	if _, ok := backend.watches[key]; !ok {
+		key = rowKey(removeRequest.PartitionKey, removeRequest.RangeKey)
+		if _, ok := backend.watches[key]; !ok {
+			return nil, notFound("Watch", removeRequest.PartitionKey, removeRequest.RangeKey)
+		}
+	}
+	delete(backend.watches, key)
+	return success()
+}
+
+func (backend *Backend) getUserWatchList(fetchRequest ticket_watch_request.TicketWatchUserListRequest, unreadOnly bool) (*model2.TicketWatchModelsResponse, error) {
+	if fetchRequest.UserId == "" {
+		return nil, badRequest("userId is required")
+	}
+	return backend.listWatches(func(row *watchRow) bool {
+		return row.PartitionKey == fetchRequest.UserId && (!unreadOnly || row.UnreadUpdates > 0)
+	}, nil, fetchRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) getTicketWatchers(fetchRequest ticket_watch_request.TicketWatchersListRequest) (*model2.TicketWatchModelsResponse, error) {
+	rangeKey := watchRangeKey(fetchRequest.TicketPartitionKey, fetchRequest.TicketRangeKey)
+	return backend.listWatches(func(row *watchRow) bool {
+		return row.RangeKey == rangeKey
+	}, fetchRequest.LastPartitionKey, fetchRequest.LastRangeKey), nil
+}
+
+func (backend *Backend) listWatches(keep func(*watchRow) bool, lastPartitionKey *string, lastRangeKey *string) *model2.TicketWatchModelsResponse {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	var rows []*watchRow
+	for _, row := range backend.watches {
+		if keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	rows, nextPartitionKey, nextRangeKey := page(rows, lastPartitionKey, lastRangeKey, backend.pageSize)
+	results := make([]*model2.TicketWatchModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, row.model())
+	}
+	return &model2.TicketWatchModelsResponse{Results: results, LastPartitionKey: nextPartitionKey, LastRangeKey: nextRangeKey}
+}
+
+func (backend *Backend) markAsRead(markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) (*bool, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rangeKey := watchRangeKey(markReadRequest.TicketPartitionKey, markReadRequest.TicketRangeKey)
+	row, ok := backend.watches[rowKey(markReadRequest.UserId, rangeKey)]
+	if !ok {
+		return nil, notFound("Watch", markReadRequest.UserId, rangeKey)
+	}
+	row.UnreadUpdates = 0
+	row.Modified = backend.timestamp()
+	return success()
+}
+
+// updateWatchEntry refreshes the ticket snapshot on a watch entry and counts it as an unread update.
+func (backend *Backend) updateWatchEntry(updateRequest ticket_watch_request.TicketWatchUpdateRequest) (*bool, error) {
+	if err := model2.TicketStatus(updateRequest.TicketStatus).Validate(); err != nil {
+		return nil, invalidValue(err)
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	rangeKey := watchRangeKey(updateRequest.TicketPartitionKey, updateRequest.TicketRangeKey)
+	row, ok := backend.watches[rowKey(updateRequest.UserId, rangeKey)]
+	if !ok {
+		return nil, notFound("Watch", updateRequest.UserId, rangeKey)
+	}
+	row.TicketTitle = updateRequest.TicketTitle
+	row.TicketStatus = updateRequest.TicketStatus
+	row.LastUpdated = updateRequest.LastUpdated
+	row.UnreadUpdates++
+	row.Modified = backend.timestamp()
+	return success()
+}
+
+// TicketWatchService is an in-memory service.TicketWatchServiceContract backed by a Backend.
+type TicketWatchService struct {
+	backend *Backend
+}
+
+var _ service.TicketWatchServiceContract = (*TicketWatchService)(nil)
+
+func NewTicketWatchService(backend *Backend) *TicketWatchService {
+	return &TicketWatchService{backend: backend}
+}
+
+func (watchService *TicketWatchService) AddWatcher(addRequest ticket_watch_request.TicketWatchAddRequest) response.Response[model2.TicketWatchModel] {
+	return watchService.AddWatcherCtx(context.Background(), addRequest)
+}
+
+func (watchService *TicketWatchService) AddWatcherCtx(ctx context.Context, addRequest ticket_watch_request.TicketWatchAddRequest) response.Response[model2.TicketWatchModel] {
+	return call(ctx, watchService.backend, "TicketWatchService.AddWatcher", func() (*model2.TicketWatchModel, error) {
+		return watchService.backend.addWatcher(addRequest)
+	})
+}
+
+func (watchService *TicketWatchService) AddWatcherWithError(ctx context.Context, addRequest ticket_watch_request.TicketWatchAddRequest) (*model2.TicketWatchModel, error) {
+	return result(watchService.AddWatcherCtx(ctx, addRequest))
+}
+
+func (watchService *TicketWatchService) RemoveWatcher(removeRequest ticket_watch_request.TicketWatchRemoveRequest) response.Response[bool] {
+	return watchService.RemoveWatcherCtx(context.Background(), removeRequest)
+}
+
+func (watchService *TicketWatchService) RemoveWatcherCtx(ctx context.Context, removeRequest ticket_watch_request.TicketWatchRemoveRequest) response.Response[bool] {
+	return call(ctx, watchService.backend, "TicketWatchService.RemoveWatcher", func() (*bool, error) {
+		return watchService.backend.removeWatcher(removeRequest)
+	})
+}
+
+func (watchService *TicketWatchService) RemoveWatcherWithError(ctx context.Context, removeRequest ticket_watch_request.TicketWatchRemoveRequest) error {
+	return service.ResponseError(watchService.RemoveWatcherCtx(ctx, removeRequest))
+}
+
+func (watchService *TicketWatchService) GetUserWatchList(fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return watchService.GetUserWatchListCtx(context.Background(), fetchRequest)
+}
+
+func (watchService *TicketWatchService) GetUserWatchListCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return call(ctx, watchService.backend, "TicketWatchService.GetUserWatchList", func() (*model2.TicketWatchModelsResponse, error) {
+		return watchService.backend.getUserWatchList(fetchRequest, false)
+	})
+}
+
+func (watchService *TicketWatchService) GetUserWatchListWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) (*model2.TicketWatchModelsResponse, error) {
+	return result(watchService.GetUserWatchListCtx(ctx, fetchRequest))
+}
+
+func (watchService *TicketWatchService) GetUserUnreadList(fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return watchService.GetUserUnreadListCtx(context.Background(), fetchRequest)
+}
+
+func (watchService *TicketWatchService) GetUserUnreadListCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return call(ctx, watchService.backend, "TicketWatchService.GetUserUnreadList", func() (*model2.TicketWatchModelsResponse, error) {
+		return watchService.backend.getUserWatchList(fetchRequest, true)
+	})
+}
+
+func (watchService *TicketWatchService) GetUserUnreadListWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchUserListRequest) (*model2.TicketWatchModelsResponse, error) {
+	return result(watchService.GetUserUnreadListCtx(ctx, fetchRequest))
+}
+
+func (watchService *TicketWatchService) GetTicketWatchers(fetchRequest ticket_watch_request.TicketWatchersListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return watchService.GetTicketWatchersCtx(context.Background(), fetchRequest)
+}
+
+func (watchService *TicketWatchService) GetTicketWatchersCtx(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchersListRequest) response.Response[model2.TicketWatchModelsResponse] {
+	return call(ctx, watchService.backend, "TicketWatchService.GetTicketWatchers", func() (*model2.TicketWatchModelsResponse, error) {
+		return watchService.backend.getTicketWatchers(fetchRequest)
+	})
+}
+
+func (watchService *TicketWatchService) GetTicketWatchersWithError(ctx context.Context, fetchRequest ticket_watch_request.TicketWatchersListRequest) (*model2.TicketWatchModelsResponse, error) {
+	return result(watchService.GetTicketWatchersCtx(ctx, fetchRequest))
+}
+
+func (watchService *TicketWatchService) MarkAsRead(markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) response.Response[bool] {
+	return watchService.MarkAsReadCtx(context.Background(), markReadRequest)
+}
+
+func (watchService *TicketWatchService) MarkAsReadCtx(ctx context.Context, markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) response.Response[bool] {
+	return call(ctx, watchService.backend, "TicketWatchService.MarkAsRead", func() (*bool, error) {
+		return watchService.backend.markAsRead(markReadRequest)
+	})
+}
+
+func (watchService *TicketWatchService) MarkAsReadWithError(ctx context.Context, markReadRequest ticket_watch_request.TicketWatchMarkReadRequest) error {
+	return service.ResponseError(watchService.MarkAsReadCtx(ctx, markReadRequest))
+}
+
+func (watchService *TicketWatchService) UpdateWatchEntry(updateRequest ticket_watch_request.TicketWatchUpdateRequest) response.Response[bool] {
+	return watchService.UpdateWatchEntryCtx(context.Background(), updateRequest)
+}
+
+func (watchService *TicketWatchService) UpdateWatchEntryCtx(ctx context.Context, updateRequest ticket_watch_request.TicketWatchUpdateRequest) response.Response[bool] {
+	return call(ctx, watchService.backend, "TicketWatchService.UpdateWatchEntry", func() (*bool, error) {
+		return watchService.backend.updateWatchEntry(updateRequest)
+	})
+}
+
+func (watchService *TicketWatchService) UpdateWatchEntryWithError(ctx context.Context, updateRequest ticket_watch_request.TicketWatchUpdateRequest) error {
+	return service.ResponseError(watchService.UpdateWatchEntryCtx(ctx, updateRequest))
+}